@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/renxzen/go-getters/pkg/clean"
+)
+
+// runClean implements the clean command.
+func runClean(args []string) int {
+	fs := newFlagSet("clean", "[directory]", `Removes the Go files generated by go-getters in the directory tree (default "."),
+those whose first line is exactly "// Code generated by go-getters. DO NOT EDIT.".
+Files from other generators and hand-written files are never removed. Like the go
+command, vendor and testdata directories are skipped, as well as those whose name
+starts with "." or "_".`)
+	var dryRun bool
+	fs.BoolVar(&dryRun, "n", false, "Dry run: list the files that would be removed, without removing them")
+	fs.BoolVar(&dryRun, "dry-run", false, "Same as -n")
+	if exitCode, ok := parseFlags(fs, args, 1); !ok {
+		return exitCode
+	}
+
+	root := "."
+	if fs.NArg() == 1 {
+		root = fs.Arg(0)
+	}
+
+	files, err := clean.Find(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	exitCode := exitOK
+	for _, file := range files {
+		if dryRun {
+			fmt.Printf("Would remove %s\n", file)
+			continue
+		}
+
+		if err := os.Remove(file); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exitCode = exitFailure
+			continue
+		}
+		fmt.Printf("Removed %s\n", file)
+	}
+
+	return exitCode
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/fileutils"
+	"github.com/renxzen/go-getters/pkg/options"
+)
+
+// report holds the flags controlling how diagnostics are written.
+type report struct {
+	format  string
+	verbose bool
+}
+
+// registerFlags defines the flags of the report in fs.
+func (r *report) registerFlags(fs *flag.FlagSet) {
+	r.format = "text"
+	fs.Func("diagnostics", "Format of the diagnostics written to stderr: text or json (default \"text\")", func(s string) error {
+		if s != "text" && s != "json" {
+			return fmt.Errorf("invalid format %q, expected text or json", s)
+		}
+		r.format = s
+		return nil
+	})
+	fs.BoolVar(&r.verbose, "verbose", false, "Also report informational diagnostics in text format")
+}
+
+// write writes the diagnostics to stderr, in the format given with
+// -diagnostics.
+func (r *report) write(diagnostics []diag.Diagnostic) {
+	var err error
+	if r.format == "json" {
+		err = diag.WriteJSON(os.Stderr, diagnostics)
+	} else {
+		err = diag.WriteText(os.Stderr, diagnostics, r.verbose)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write diagnostics: %v\n", err)
+	}
+}
+
+// generate generates the getters described by opts and writes the diagnostics
+// found. It returns the exit code to stop with if generation failed.
+func (r *report) generate(opts *options.Options) (res *options.Result, exitCode int, ok bool) {
+	res, err := options.Generate(opts)
+	r.write(res.Diagnostics)
+	if err == nil {
+		return res, exitOK, true
+	}
+
+	// Errors already reported as diagnostics are not repeated
+	var diags diag.List
+	diags.Append(res.Diagnostics...)
+	if len(diags.Filter(diag.SeverityError)) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	if errors.Is(err, options.ErrNoStructs) {
+		return res, exitUsage, false
+	}
+
+	return res, exitFailure, false
+}
+
+// runGenerate implements the generate command.
+func runGenerate(args []string) int {
+	fs := newFlagSet("generate", "", `Generates getters for the structs of the package in the input directory, selected
+with -structs, -all or the configuration file, and writes them to the output file.
+The file is left untouched when it is up to date.
+
+Run by go generate without -structs or -all, as in "//go:generate go-getters", it
+selects the struct declared right after the directive, if any. The package is
+the one named by $GOPACKAGE, unless -input is another directory.`)
+	var opts options.Options
+	opts.RegisterFlags(fs)
+	var rep report
+	rep.registerFlags(fs)
+	if exitCode, ok := parseFlags(fs, args, 0); !ok {
+		return exitCode
+	}
+	opts.ReadGoGenerateEnv(os.Getenv)
+
+	res, exitCode, ok := rep.generate(&opts)
+	if !ok || res.Code == nil {
+		return exitCode
+	}
+
+	if err := os.MkdirAll(filepath.Dir(res.Path), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create output directory: %v\n", err)
+		return exitFailure
+	}
+	written, err := fileutils.WriteFile(res.Path, res.Code, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write output file: %v\n", err)
+		return exitFailure
+	}
+
+	if written {
+		fmt.Printf("Generated getters for %d struct(s) in %s\n", len(res.Structs), res.Path)
+	} else {
+		fmt.Printf("Getters for %d struct(s) in %s are unchanged\n", len(res.Structs), res.Path)
+	}
+	return exitOK
+}
+
+// runCheck implements the check command.
+func runCheck(args []string) int {
+	fs := newFlagSet("check", "", `Checks that the output file holds the getters generate would write, without
+writing anything. It exits with code 1 if the file is missing or out of date.
+It takes the flags of generate.`)
+	var opts options.Options
+	opts.RegisterFlags(fs)
+	var rep report
+	rep.registerFlags(fs)
+	if exitCode, ok := parseFlags(fs, args, 0); !ok {
+		return exitCode
+	}
+	opts.ReadGoGenerateEnv(os.Getenv)
+
+	res, exitCode, ok := rep.generate(&opts)
+	if !ok || res.Code == nil {
+		return exitCode
+	}
+
+	current, err := os.ReadFile(res.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		fmt.Fprintf(os.Stderr, "%s is missing, run %s generate\n", res.Path, program())
+		return exitFailure
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	case !bytes.Equal(current, res.Code):
+		fmt.Fprintf(os.Stderr, "%s is out of date, run %s generate\n", res.Path, program())
+		return exitFailure
+	}
+
+	fmt.Printf("%s is up to date\n", res.Path)
+	return exitOK
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/config"
+	"github.com/renxzen/go-getters/pkg/fileutils"
+	"github.com/renxzen/go-getters/pkg/options"
+	"github.com/renxzen/go-getters/pkg/parser"
+)
+
+// directiveFile is the file holding the go:generate directive written by init.
+const directiveFile = "generate.go"
+
+// runInit implements the init command.
+func runInit(args []string) int {
+	fs := newFlagSet("init", "[directory]", `Writes a starter `+config.FileName+` configuration file in the directory
+(default "."), including the structs given with -structs, else every struct declared at
+the top level of the package by name, and a `+directiveFile+` file
+running go-getters with go generate. Existing files are kept unless -force is given.`)
+	structs := fs.String("structs", "", "Comma-separated list of struct names or glob patterns included by the configuration (default: the structs of the package)")
+	force := fs.Bool("force", false, "Overwrite existing files")
+	if exitCode, ok := parseFlags(fs, args, 1); !ok {
+		return exitCode
+	}
+
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+
+	if err := initPackage(dir, *structs, *force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// initPackage writes the configuration file and the go:generate directive of
+// the package in dir.
+func initPackage(dir, structs string, force bool) error {
+	result, err := parser.New().ParseDirectory(dir)
+	if err != nil {
+		return err
+	}
+	if result.PackageName == "" {
+		return fmt.Errorf("no Go package in %s", dir)
+	}
+
+	var cfg config.Config
+	if structs != "" {
+		for _, pattern := range strings.Split(structs, ",") {
+			cfg.Include = append(cfg.Include, strings.TrimSpace(pattern))
+		}
+	} else {
+		cfg.Include = slices.Sorted(maps.Keys(result.Structs))
+	}
+	if len(cfg.Include) == 0 {
+		return fmt.Errorf("no structs declared in %s, use -structs to include some", dir)
+	}
+	output := options.DefaultOutput
+	cfg.Output = &output
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	directive := fmt.Sprintf("package %s\n\n//go:generate go-getters generate\n", result.PackageName)
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{config.FileName, append(data, '\n')},
+		{directiveFile, []byte(directive)},
+	}
+	// Nothing is written if any of the files exists
+	if !force {
+		for _, file := range files {
+			path := filepath.Join(dir, file.name)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists, use -force to overwrite it", path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if _, err := fileutils.WriteFile(path, file.data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/inspect"
+	"github.com/renxzen/go-getters/pkg/parser"
+)
+
+// runInspect implements the inspect command.
+func runInspect(args []string) int {
+	fs := newFlagSet("inspect", "[directory]", fmt.Sprintf(`Writes the structs, fields, imports and named types parsed from the directory
+(default ".") as JSON, with schema version %d. Parsing issues are reported on
+stderr.`, inspect.SchemaVersion))
+	if exitCode, ok := parseFlags(fs, args, 1); !ok {
+		return exitCode
+	}
+
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+
+	p := parser.New()
+	result, err := p.ParseDirectory(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := diag.WriteText(os.Stderr, p.Diagnostics(), false); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := inspect.Write(os.Stdout, result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	return exitOK
+}
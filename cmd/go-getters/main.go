@@ -3,84 +3,164 @@ package main
 import (
 	"flag"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
-
-	"github.com/renxzen/go-getters/pkg/generator"
-	"github.com/renxzen/go-getters/pkg/parser"
 )
 
-var (
-	inputPath   = flag.String("input", ".", "Path to directory containing Go files")
-	outputFile  = flag.String("output", "getters.gen.go", "Output file path")
-	structNames = flag.String("structs", "", "Comma-separated list of struct names to generate getters for")
-	help        = flag.Bool("help", false, "Show help message")
+// Exit codes shared by all commands.
+const (
+	exitOK      = 0 // The command succeeded
+	exitFailure = 1 // The command failed, or check found stale files
+	exitUsage   = 2 // The command line is invalid
 )
 
+// command is a subcommand of go-getters.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// commands lists the subcommands, generate being the default one.
+var commands = []command{
+	{name: "generate", summary: "Generate getters for the structs of a package", run: runGenerate},
+	{name: "check", summary: "Check that the generated file is up to date", run: runCheck},
+	{name: "inspect", summary: "Write the model parsed from a directory as JSON", run: runInspect},
+	{name: "clean", summary: "Remove the files generated by go-getters in a directory tree", run: runClean},
+	{name: "init", summary: "Write a starter configuration file and go:generate directive", run: runInit},
+}
+
 func main() {
-	flag.Parse()
+	os.Exit(run(os.Args[1:]))
+}
 
-	if *help {
-		showHelp()
-		return
+// run runs the command named by the first argument, or generate when the
+// arguments start with a flag, and returns the exit code.
+func run(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		if len(args) > 0 && isHelpFlag(args[0]) {
+			usage()
+			return exitOK
+		}
+		return runGenerate(args)
 	}
 
-	if *structNames == "" {
-		fmt.Fprintf(os.Stderr, "Error: -structs flag is required\n")
-		showHelp()
-		os.Exit(1)
+	if args[0] == "help" {
+		return runHelp(args[1:])
+	}
+
+	if cmd := findCommand(args[0]); cmd != nil {
+		return cmd.run(args[1:])
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+	usage()
+	return exitUsage
+}
+
+// runHelp implements the help command, showing the usage of a command or of
+// go-getters itself.
+func runHelp(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return exitOK
 	}
 
-	// Parse struct names
-	structs := strings.Split(*structNames, ",")
-	for i, s := range structs {
-		structs[i] = strings.TrimSpace(s)
+	cmd := findCommand(args[0])
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+		usage()
+		return exitUsage
 	}
 
-	// Parse the directory to extract struct information
-	p := parser.New()
-	result, err := p.ParseDirectory(*inputPath)
-	if err != nil {
-		log.Fatalf("Failed to parse directory: %v", err)
+	return cmd.run([]string{"-help"})
+}
+
+// findCommand returns the command with the given name, or nil.
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
 	}
 
-	// Generate getters
-	gen := generator.New()
-	outBytes, err := gen.GenerateGetters(structs, result)
-	if err != nil {
-		log.Fatalf("Failed to generate getters: %v", err)
+	return nil
+}
+
+// isHelpFlag reports whether arg asks for help.
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	default:
+		return false
 	}
+}
 
-	// Write output
-	outputFilePath := filepath.Join(*inputPath, filepath.Base(*outputFile))
-	if err := os.WriteFile(outputFilePath, outBytes, 0644); err != nil {
-		log.Fatalf("Failed to write output file: %v", err)
+// newFlagSet returns the flag set of a command taking the given arguments,
+// printing its usage line, its description and its flags on -help.
+func newFlagSet(name, args, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s\n\n%s\n", strings.TrimSpace(program()+" "+name+" [flags] "+args), description)
+
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintf(fs.Output(), "\nFlags:\n")
+			fs.PrintDefaults()
+		}
 	}
 
-	fmt.Printf("Generated getters for %d struct(s) in %s\n", len(structs), outputFilePath)
+	return fs
 }
 
-func showHelp() {
-	fmt.Printf(`go-getters - Generate getter methods for Go structs
+// parseFlags parses the arguments of a command, which takes at most maxArgs
+// arguments after its flags. It reports whether the command must run, and
+// otherwise the code to exit with: exitOK for -help, exitUsage for an invalid
+// command line.
+func parseFlags(fs *flag.FlagSet, args []string, maxArgs int) (exitCode int, ok bool) {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+
+	if fs.NArg() > maxArgs {
+		fmt.Fprintf(fs.Output(), "Error: unexpected arguments %s\n\n", strings.Join(fs.Args()[maxArgs:], " "))
+		fs.Usage()
+		return exitUsage, false
+	}
+
+	return exitOK, true
+}
 
-Usage:
-  %s [options]
+// usage prints the usage of go-getters.
+func usage() {
+	fmt.Fprintf(os.Stderr, "%s - Generate getter methods for Go structs\n\n", program())
+	fmt.Fprintf(os.Stderr, "Usage:\n  %s <command> [flags] [arguments]\n  %s [generate flags]\n\nCommands:\n", program(), program())
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, `
+Run "%s help <command>" for the flags of a command.
 
-Options:
-  -input string
-        Path to directory containing Go files (default ".")
-  -output string
-        Output file name (default "getters.gen.go"). The file will be created in the input directory.
-  -structs string
-        Comma-separated list of struct names to generate getters for (required)
-  -help
-        Show this help message
+Exit codes:
+  0  success
+  1  failure, or out of date files found by check
+  2  invalid command line
 
 Examples:
-  %s -structs="User,Product"
-  %s -input=./models -output=getters.go -structs="User,Product,Order"
+  %s generate -structs="User,Product"
+  %s generate -input=./models -output=getters.go -structs="User,Product,Order"
+  %s check -structs="*Request,*Response" -exclude="^Internal"
+  %s inspect ./models
+`, program(), program(), program(), program(), program())
+}
 
-`, filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]))
+// program returns the name go-getters was run with.
+func program() string {
+	return filepath.Base(os.Args[0])
 }
@@ -1,6 +1,6 @@
 package item
 
-//go:generate go-getters -structs=Item -output=getters.go
+//go:generate go-getters generate -structs=Item -output=getters.go
 
 type Item struct {
 	Name   *string
@@ -7,7 +7,7 @@ import (
 	it "github.com/renxzen/go-getters/examples/order/item"
 )
 
-//go:generate go-getters -structs=Order -output=getters.go
+//go:generate go-getters generate -structs=Order -output=getters.go
 
 type Order struct {
 	ID         int
@@ -0,0 +1,74 @@
+// Package clean finds the files generated by go-getters in a directory tree,
+// so that stale ones can be removed after renaming an output file or moving
+// structs.
+package clean
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// Find returns the Go files of the tree rooted at root whose first line is
+// exactly types.GeneratedHeader, in lexical order. Like the go command, it
+// skips vendor and testdata directories, and those whose name starts with
+// "." or "_", except root itself.
+func Find(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		generated, err := isGenerated(path)
+		if err != nil {
+			return err
+		}
+		if generated {
+			files = append(files, path)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// skipDir reports whether the go command ignores the directory.
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// isGenerated reports whether the first line of the file is exactly
+// types.GeneratedHeader, reading no more than that line.
+func isGenerated(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	// One more byte than the header and a "\r\n" line break tells longer
+	// first lines apart
+	line := make([]byte, len(types.GeneratedHeader)+3)
+	n, err := io.ReadFull(f, line)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+
+	return types.IsGenerated(line[:n]), nil
+}
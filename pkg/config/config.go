@@ -0,0 +1,423 @@
+// Package config reads the .go-getters.json project configuration file.
+//
+// The file holds settings for every package of the project, which packages
+// and structs can override:
+//
+//	{
+//		"getterPrefix": "Get",
+//		"copy": "shallow",
+//		"packages": {
+//			"internal/models": {
+//				"include": ["User", "Order*"],
+//				"setters": true,
+//				"structs": {
+//					"Money": {"valueReceiver": true, "receiver": "m"}
+//				}
+//			}
+//		}
+//	}
+//
+// Settings are applied from the least to the most specific: the project, the
+// packages matching the input directory, then the struct. Directives and getter
+// tags in the source take precedence over the file, and command line flags
+// over all of them.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/renxzen/go-getters/pkg/generator"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// FileName is the name of the configuration file.
+const FileName = ".go-getters.json"
+
+// Settings are the generation settings that can be given for the project, a
+// package or a struct. Nil fields are unset.
+type Settings struct {
+	GetterPrefix    *string `json:"getterPrefix,omitempty"`
+	SetterPrefix    *string `json:"setterPrefix,omitempty"`
+	Deref           *string `json:"deref,omitempty"`
+	Copy            *string `json:"copy,omitempty"`
+	Receiver        *string `json:"receiver,omitempty"`
+	ValueReceiver   *bool   `json:"valueReceiver,omitempty"`
+	Iterators       *bool   `json:"iter,omitempty"`
+	Accessors       *bool   `json:"accessors,omitempty"`
+	Setters         *bool   `json:"setters,omitempty"`
+	Interfaces      *bool   `json:"interfaces,omitempty"`
+	InterfaceName   *string `json:"interfaceName,omitempty"`
+	Fields          *bool   `json:"fields,omitempty"`
+	FieldAccess     *bool   `json:"fieldAccess,omitempty"`
+	Paths           *int    `json:"paths,omitempty"`
+	Docs            *bool   `json:"docs,omitempty"`
+	DeprecationHook *string `json:"deprecationHook,omitempty"`
+}
+
+// Package holds the settings of a package, along with the structs to generate
+// getters for, the output file and the settings of single structs.
+type Package struct {
+	Settings
+
+	// Include lists the structs to generate getters for, as names or
+	// path.Match patterns, when no structs are given on the command line.
+	Include []string `json:"include,omitempty"`
+	// Exclude lists the structs never generated, as path.Match patterns.
+	Exclude []string `json:"exclude,omitempty"`
+	// Output is the path of the generated file, relative to the package
+	// directory. In another directory, functions of its package are generated.
+	Output *string `json:"output,omitempty"`
+	// Structs holds the settings of single structs, by struct name.
+	Structs map[string]Settings `json:"structs,omitempty"`
+}
+
+// Config is the content of a configuration file.
+type Config struct {
+	Package
+
+	// Packages holds the settings of packages, by path.Match pattern of their
+	// directory relative to the one of the configuration file, such as
+	// "internal/models" or "internal/*".
+	Packages map[string]Package `json:"packages,omitempty"`
+
+	// Path is the path of the file the configuration was read from.
+	Path string `json:"-"`
+}
+
+// Find reads the configuration file of the directory dir, looked up in dir
+// and its parents. It returns nil if there is none.
+func Find(dir string) (*Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		configPath := filepath.Join(dir, FileName)
+		if _, err := os.Stat(configPath); err == nil {
+			return Load(configPath)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates a configuration file.
+func Load(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	config := &Config{Path: configPath}
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	return config, nil
+}
+
+// Validate checks the values of the configuration, reporting every invalid one.
+func (c *Config) Validate() error {
+	errs := c.Package.validate("")
+	for _, pattern := range slices.Sorted(maps.Keys(c.Packages)) {
+		prefix := fmt.Sprintf("packages[%q].", pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("packages[%q]: invalid pattern: %w", pattern, err))
+		}
+
+		pkg := c.Packages[pattern]
+		errs = append(errs, pkg.validate(prefix)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks the values of the package settings, prefixing the errors
+// with the location of the package in the file.
+func (p *Package) validate(prefix string) []error {
+	errs := p.Settings.validate(prefix)
+
+	for _, pattern := range p.Include {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("%sinclude: invalid pattern %q: %w", prefix, pattern, err))
+		}
+	}
+	for _, pattern := range p.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("%sexclude: invalid pattern %q: %w", prefix, pattern, err))
+		}
+	}
+
+	if p.Output != nil && (*p.Output == "" || filepath.Ext(*p.Output) != ".go") {
+		errs = append(errs, fmt.Errorf("%soutput: %q must be the path of a .go file", prefix, *p.Output))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(p.Structs)) {
+		settings := p.Structs[name]
+		errs = append(errs, settings.validate(fmt.Sprintf("%sstructs[%q].", prefix, name))...)
+	}
+
+	return errs
+}
+
+// Validate checks the values of the settings, reporting every invalid one.
+func (s *Settings) Validate() error {
+	return errors.Join(s.validate("")...)
+}
+
+// validate checks the values of the settings, prefixing the errors with their
+// location in the file.
+func (s *Settings) validate(prefix string) []error {
+	var errs []error
+	fail := func(field string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s%s: %s", prefix, field, fmt.Sprintf(format, args...)))
+	}
+
+	if s.GetterPrefix != nil && *s.GetterPrefix == "" {
+		fail("getterPrefix", "must not be empty, since getters can't be named like their fields")
+	} else if s.GetterPrefix != nil && !token.IsIdentifier(*s.GetterPrefix) {
+		fail("getterPrefix", "%q is not a valid identifier", *s.GetterPrefix)
+	}
+	if s.SetterPrefix != nil && !token.IsIdentifier(*s.SetterPrefix) {
+		fail("setterPrefix", "%q is not a valid identifier", *s.SetterPrefix)
+	}
+	if s.Deref != nil {
+		if _, err := types.ParseDerefPolicy(*s.Deref); err != nil {
+			fail("deref", "%v", err)
+		}
+	}
+	if s.Copy != nil {
+		if _, err := types.ParseCopyMode(*s.Copy); err != nil {
+			fail("copy", "%v", err)
+		}
+	}
+	if s.Receiver != nil && (!token.IsIdentifier(*s.Receiver) || *s.Receiver == "_") {
+		fail("receiver", "%q is not a valid receiver name", *s.Receiver)
+	}
+	if s.InterfaceName != nil {
+		if _, err := template.New("interface").Parse(*s.InterfaceName); err != nil {
+			fail("interfaceName", "%v", err)
+		}
+	}
+	if s.Paths != nil && *s.Paths < 0 {
+		fail("paths", "depth %d must not be negative", *s.Paths)
+	}
+	if s.DeprecationHook != nil {
+		if _, name := types.SplitQualifiedType(*s.DeprecationHook); !token.IsIdentifier(name) {
+			fail("deprecationHook", "%q is not a function name such as log.Print", *s.DeprecationHook)
+		}
+	}
+
+	return errs
+}
+
+// PackageAt returns the settings of the package in the directory dir: those of
+// the project, overridden by those of every package pattern matching dir,
+// from the shortest pattern to the longest.
+func (c *Config) PackageAt(dir string) (Package, error) {
+	rel, err := c.relativeDir(dir)
+	if err != nil {
+		return Package{}, err
+	}
+
+	pkg := c.Package.clone()
+	patterns := slices.SortedFunc(maps.Keys(c.Packages), func(a, b string) int {
+		return cmpLength(a, b)
+	})
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, rel); matched {
+			pkg.merge(c.Packages[pattern])
+		}
+	}
+
+	return pkg, nil
+}
+
+// relativeDir returns the slash-separated path of dir relative to the
+// directory of the configuration file.
+func (c *Config) relativeDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := filepath.Abs(filepath.Dir(c.Path))
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// Struct returns the settings of a struct of the package: those of the
+// package, overridden by those given for the struct.
+func (p *Package) Struct(name string) Settings {
+	settings := p.Settings
+	settings.merge(p.Structs[name])
+	return settings
+}
+
+// clone returns a copy of the package settings that can be merged into
+// without modifying the original.
+func (p Package) clone() Package {
+	p.Include = slices.Clone(p.Include)
+	p.Exclude = slices.Clone(p.Exclude)
+
+	structs := make(map[string]Settings, len(p.Structs))
+	for name, settings := range p.Structs {
+		structs[name] = settings
+	}
+	p.Structs = structs
+
+	return p
+}
+
+// merge overrides the package settings with those set in other. Include and
+// exclude patterns are replaced, and struct settings merged.
+func (p *Package) merge(other Package) {
+	p.Settings.merge(other.Settings)
+	if other.Include != nil {
+		p.Include = other.Include
+	}
+	if other.Exclude != nil {
+		p.Exclude = other.Exclude
+	}
+	if other.Output != nil {
+		p.Output = other.Output
+	}
+	for name, settings := range other.Structs {
+		merged := p.Structs[name]
+		merged.merge(settings)
+		p.Structs[name] = merged
+	}
+}
+
+// merge overrides the settings with those set in other.
+func (s *Settings) merge(other Settings) {
+	mergeValue(&s.GetterPrefix, other.GetterPrefix)
+	mergeValue(&s.SetterPrefix, other.SetterPrefix)
+	mergeValue(&s.Deref, other.Deref)
+	mergeValue(&s.Copy, other.Copy)
+	mergeValue(&s.Receiver, other.Receiver)
+	mergeValue(&s.ValueReceiver, other.ValueReceiver)
+	mergeValue(&s.Iterators, other.Iterators)
+	mergeValue(&s.Accessors, other.Accessors)
+	mergeValue(&s.Setters, other.Setters)
+	mergeValue(&s.Interfaces, other.Interfaces)
+	mergeValue(&s.InterfaceName, other.InterfaceName)
+	mergeValue(&s.Fields, other.Fields)
+	mergeValue(&s.FieldAccess, other.FieldAccess)
+	mergeValue(&s.Paths, other.Paths)
+	mergeValue(&s.Docs, other.Docs)
+	mergeValue(&s.DeprecationHook, other.DeprecationHook)
+}
+
+// mergeValue replaces the value of dst with src when src is set.
+func mergeValue[T any](dst **T, src *T) {
+	if src != nil {
+		*dst = src
+	}
+}
+
+// Options returns the generator options applying the settings to the named
+// struct, or to all of them when structName is empty, except for valueReceiver
+// which only applies to named structs. The settings must have been validated.
+func (s *Settings) Options(structName string) []generator.Option {
+	var opts []generator.Option
+	if s.GetterPrefix != nil || s.SetterPrefix != nil {
+		opts = append(opts, generator.WithMethodPrefixes(deref(s.GetterPrefix), deref(s.SetterPrefix)))
+	}
+	if s.Deref != nil {
+		opts = append(opts, generator.WithDeref(types.DerefPolicy(*s.Deref)))
+	}
+	if s.Copy != nil {
+		opts = append(opts, generator.WithCopyMode(types.CopyMode(*s.Copy)))
+	}
+	if s.Receiver != nil {
+		opts = append(opts, generator.WithReceiver(*s.Receiver))
+	}
+	if structName != "" && deref(s.ValueReceiver) {
+		opts = append(opts, generator.WithValueReceivers(structName))
+	}
+	if s.Iterators != nil {
+		opts = append(opts, generator.WithIterators(*s.Iterators))
+	}
+	if s.Accessors != nil {
+		opts = append(opts, generator.WithAccessors(*s.Accessors))
+	}
+	if s.Setters != nil {
+		opts = append(opts, generator.WithSetters(*s.Setters))
+	}
+	if s.Interfaces != nil {
+		opts = append(opts, generator.WithInterfaces(*s.Interfaces, ""))
+	}
+	if s.InterfaceName != nil {
+		opts = append(opts, generator.WithInterfaceName(*s.InterfaceName))
+	}
+	if s.Fields != nil {
+		opts = append(opts, generator.WithFieldMetadata(*s.Fields))
+	}
+	if s.FieldAccess != nil {
+		opts = append(opts, generator.WithFieldAccess(*s.FieldAccess))
+	}
+	if s.Paths != nil {
+		opts = append(opts, generator.WithPathGetters(*s.Paths))
+	}
+	if s.Docs != nil {
+		opts = append(opts, generator.WithDocComments(*s.Docs))
+	}
+	if s.DeprecationHook != nil {
+		opts = append(opts, generator.WithDeprecationHook(*s.DeprecationHook))
+	}
+
+	return opts
+}
+
+// deref returns the value v points to, or its zero value if v is nil.
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+
+	return *v
+}
+
+// cmpLength orders strings by length, then alphabetically.
+func cmpLength(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,197 @@
+// Package diag records the diagnostics reported while parsing and generating:
+// positioned issues with a severity and a stable code.
+package diag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"slices"
+)
+
+// Severity tells how serious a diagnostic is.
+type Severity string
+
+const (
+	// SeverityError marks issues preventing generation.
+	SeverityError Severity = "error"
+	// SeverityWarning marks issues the generated code works around, or that
+	// may make it fail to compile.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks notes about choices made by the generator.
+	SeverityInfo Severity = "info"
+)
+
+// Code identifies a kind of diagnostic. Codes are stable, so that tools can
+// filter diagnostics by them.
+type Code string
+
+const (
+	// MissingStruct reports a requested struct the package doesn't declare.
+	MissingStruct Code = "missing-struct"
+	// UnmatchedPattern reports a struct pattern matching no struct.
+	UnmatchedPattern Code = "unmatched-pattern"
+	// UnsupportedType reports a field type the generated methods refer to as any.
+	UnsupportedType Code = "unsupported-type"
+	// DerefAmbiguity reports a getter returning the same value for a nil
+	// pointer and a pointer to the zero value.
+	DerefAmbiguity Code = "deref-ambiguity"
+	// NoCopy reports a field left without methods since it holds a value
+	// that must not be copied, like a mutex.
+	NoCopy Code = "no-copy"
+	// NameConflict reports a generated method named like another method or a field.
+	NameConflict Code = "name-conflict"
+	// ValueReceiver reports value receivers that can't be used or that are
+	// mixed with pointer receivers.
+	ValueReceiver Code = "value-receiver"
+	// ReceiverName reports a receiver name that can't be used.
+	ReceiverName Code = "receiver-name"
+	// UnknownOption reports an unknown getter tag option or directive.
+	UnknownOption Code = "unknown-option"
+	// OutputPackage reports declarations and options that can't be generated
+	// in another package than the source one.
+	OutputPackage Code = "output-package"
+)
+
+// Diagnostic is an issue found in the source or while generating.
+type Diagnostic struct {
+	Position token.Position // Position of the issue, invalid if it has none
+	Severity Severity
+	Code     Code
+	Message  string
+}
+
+// String formats the diagnostic as "file:line:col: severity: message [code]",
+// without the position when it has none.
+func (d Diagnostic) String() string {
+	msg := fmt.Sprintf("%s: %s [%s]", d.Severity, d.Message, d.Code)
+	if d.Position.IsValid() {
+		return d.Position.String() + ": " + msg
+	}
+
+	return msg
+}
+
+// Error implements the error interface for error diagnostics.
+func (d Diagnostic) Error() string {
+	return d.String()
+}
+
+// MarshalJSON encodes the diagnostic with its position split into file, line
+// and column, omitted when it has none.
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	type position struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	}
+
+	var pos *position
+	if d.Position.IsValid() {
+		pos = &position{File: d.Position.Filename, Line: d.Position.Line, Column: d.Position.Column}
+	}
+
+	return json.Marshal(struct {
+		*position
+		Severity Severity `json:"severity"`
+		Code     Code     `json:"code"`
+		Message  string   `json:"message"`
+	}{pos, d.Severity, d.Code, d.Message})
+}
+
+// List collects diagnostics, in the order they are reported.
+type List struct {
+	diagnostics []Diagnostic
+}
+
+// Add records a diagnostic, unless the same one was already recorded.
+func (l *List) Add(pos token.Position, severity Severity, code Code, format string, args ...any) {
+	d := Diagnostic{
+		Position: pos,
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+	}
+
+	if !slices.Contains(l.diagnostics, d) {
+		l.diagnostics = append(l.diagnostics, d)
+	}
+}
+
+// Errorf records an error.
+func (l *List) Errorf(pos token.Position, code Code, format string, args ...any) {
+	l.Add(pos, SeverityError, code, format, args...)
+}
+
+// Warnf records a warning.
+func (l *List) Warnf(pos token.Position, code Code, format string, args ...any) {
+	l.Add(pos, SeverityWarning, code, format, args...)
+}
+
+// Infof records an informational note.
+func (l *List) Infof(pos token.Position, code Code, format string, args ...any) {
+	l.Add(pos, SeverityInfo, code, format, args...)
+}
+
+// Append records the diagnostics of another list.
+func (l *List) Append(diagnostics ...Diagnostic) {
+	for _, d := range diagnostics {
+		l.Add(d.Position, d.Severity, d.Code, "%s", d.Message)
+	}
+}
+
+// All returns the recorded diagnostics.
+func (l *List) All() []Diagnostic {
+	return l.diagnostics
+}
+
+// Filter returns the recorded diagnostics of the given severity.
+func (l *List) Filter(severity Severity) []Diagnostic {
+	var filtered []Diagnostic
+	for _, d := range l.diagnostics {
+		if d.Severity == severity {
+			filtered = append(filtered, d)
+		}
+	}
+
+	return filtered
+}
+
+// Err returns the recorded errors joined, or nil if there are none.
+func (l *List) Err() error {
+	var errs []error
+	for _, d := range l.Filter(SeverityError) {
+		errs = append(errs, d)
+	}
+
+	return errors.Join(errs...)
+}
+
+// WriteText writes the diagnostics one per line, leaving out info notes
+// unless verbose is set.
+func WriteText(w io.Writer, diagnostics []Diagnostic, verbose bool) error {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityInfo && !verbose {
+			continue
+		}
+
+		if _, err := fmt.Fprintln(w, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the diagnostics as a JSON array.
+func WriteJSON(w io.Writer, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagnostics)
+}
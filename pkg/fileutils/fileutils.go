@@ -0,0 +1,91 @@
+// Package fileutils writes generated files without disturbing the tools
+// watching them: an interrupted write never leaves a truncated file, and
+// unchanged files are not touched, so that build caches stay valid.
+package fileutils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// WriteFile writes data to the named file unless it already holds it, and
+// reports whether it was written. The data is written to a temporary file of
+// the same directory, which then replaces the file, so that readers see
+// either the old or the new content. An existing file keeps its mode, and a
+// new one is created with perm, masked by the umask like with os.WriteFile.
+// Symbolic links are followed.
+func WriteFile(name string, data []byte, perm fs.FileMode) (written bool, err error) {
+	if target, err := filepath.EvalSymlinks(name); err == nil {
+		name = target
+	}
+
+	keepMode := false
+	info, err := os.Stat(name)
+	switch {
+	case err == nil:
+		current, err := os.ReadFile(name)
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(current, data) {
+			return false, nil
+		}
+		perm, keepMode = info.Mode().Perm(), true
+	case !errors.Is(err, fs.ErrNotExist):
+		return false, err
+	}
+
+	tmp, err := createTemp(name, perm)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return false, err
+	}
+	// The umask doesn't apply to the mode of the replaced file
+	if keepMode {
+		if err = tmp.Chmod(perm); err != nil {
+			return false, err
+		}
+	}
+	if err = tmp.Sync(); err != nil {
+		return false, err
+	}
+	if err = tmp.Close(); err != nil {
+		return false, err
+	}
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createTemp creates a new temporary file next to the named one, with the
+// mode perm masked by the umask. Unlike os.CreateTemp, which always uses
+// 0600, this gives new files the mode os.WriteFile would. The temporary file
+// is hidden from the go command by its leading dot.
+func createTemp(name string, perm fs.FileMode) (*os.File, error) {
+	prefix := filepath.Join(filepath.Dir(name), "."+filepath.Base(name)+".")
+	for range 10000 {
+		tmp, err := os.OpenFile(prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+".tmp", os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if !errors.Is(err, fs.ErrExist) {
+			return tmp, err
+		}
+	}
+
+	return nil, fmt.Errorf("failed to create a temporary file for %s", name)
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"strconv"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// generateFieldAccess generates methods accessing the fields with a getter by
+// their name, dispatching to the typed getters and setters: GetField,
+// FieldNames and, when setters are enabled, SetField.
+func (g *Generator) generateFieldAccess(structInfo *types.StructInfo) {
+	var fields []types.FieldInfo
+	for _, field := range structInfo.Fields {
+		if hasGetter(field) {
+			fields = append(fields, field)
+		}
+	}
+
+	name := g.local("name")
+
+	g.doc("GetField returns the value of the named field through its getter, and whether the field exists.")
+	g.openGetter(structInfo.Name, "GetField(", name, " string) (any, bool)")
+	if len(fields) > 0 {
+		g.Line("switch ", name, " {")
+		for _, field := range fields {
+			g.Line("case ", strconv.Quote(field.Name), ":")
+			g.Line("return ", g.recv, ".", g.getterName(field), "(), true")
+		}
+		g.Line("}")
+	}
+	g.Line("return nil, false")
+	g.Line("}")
+	g.Line()
+
+	g.doc("FieldNames returns the names of the fields accessible with GetField.")
+	g.openGetter(structInfo.Name, "FieldNames() []string")
+	g.Line("return []string{")
+	for _, field := range fields {
+		g.Line(strconv.Quote(field.Name), ",")
+	}
+	g.Line("}")
+	g.Line("}")
+	g.Line()
+
+	if !g.opts.Setters {
+		return
+	}
+
+	fmtPackage := g.importPackage("fmt")
+	value, v, ok := g.local("value"), g.local("v"), g.local("ok")
+	g.doc("SetField sets the named field through its setter. It returns an error if",
+		"the field doesn't exist or the value doesn't have the type of the field.")
+	g.openMethod(structInfo.Name, "SetField(", name, " string, ", value, " any) error")
+	if len(fields) > 0 {
+		g.Line("switch ", name, " {")
+		for _, field := range fields {
+			valueType := setterType(field)
+			g.Line("case ", strconv.Quote(field.Name), ":")
+			g.Line(v, ", ", ok, " := ", value, ".(", valueType, ")")
+			g.Line("if !", ok, " {")
+			g.Line(`return `, fmtPackage, `.Errorf("field `, field.Name, `: cannot assign value of type %T to `, valueType, `", `, value, `)`)
+			g.Line("}")
+			g.Line(g.recv, ".", g.setterName(field), "(", v, ")")
+			g.Line("return nil")
+		}
+		g.Line("}")
+	}
+	g.Line(`return `, fmtPackage, `.Errorf("unknown field %q", `, name, `)`)
+	g.Line("}")
+	g.Line()
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"github.com/renxzen/go-getters/pkg/strutils"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// generateFieldAccessors generates the element accessors of a slice or map field.
+// Map fields get Lookup<Field>(k) (V, bool), slice fields get <Field>At(i) (T, bool)
+// and both get <Field>Len() int. All of them are safe on a nil receiver and on
+// a nil pointer to the collection. In deep copy mode, elements holding slices
+// or maps are copied like by the getter.
+func (g *Generator) generateFieldAccessors(structName string, field types.FieldInfo) {
+	if field.Elem == nil || field.IsMap && field.Key == nil {
+		return
+	}
+
+	fieldName := field.MethodName()
+	value := g.recv + "." + field.Name
+	indexed := value
+	if field.IsPointer {
+		value = "*" + g.recv + "." + field.Name
+		indexed = "(" + value + ")"
+	}
+
+	k, i, v, ok := g.local("k"), g.local("i"), g.local("v"), g.local("ok")
+	if field.IsMap {
+		g.methodDoc(field.Deprecated, "Lookup"+fieldName+" returns the value stored under "+k+" in the "+field.Name+" field and whether it is present.")
+		g.openGetter(structName, "Lookup", fieldName, "(", k, " ", field.Key.Type, ") (", v, " ", field.Elem.Type, ", ", ok, " bool)")
+		depth := g.openNilCheck(field, field.IsPointer, "")
+		g.Line(v, ", ", ok, " = ", indexed, "[", k, "]")
+		if g.copiesElements(field) {
+			g.writeElemCopy(v, v, field)
+		}
+		g.closeBlocks(depth)
+		g.Line("return ", v, ", ", ok)
+		g.Line("}")
+		g.Line()
+	} else {
+		g.methodDoc(field.Deprecated, strutils.Singularize(fieldName)+"At returns the element at index "+i+" of the "+field.Name+" field and whether "+i+" is in range.")
+		g.openGetter(structName, strutils.Singularize(fieldName), "At(", i, " int) (", v, " ", field.Elem.Type, ", ", ok, " bool)")
+		depth := g.openNilCheck(field, field.IsPointer, i+" >= 0 && "+i+" < len("+value+")")
+		if g.copiesElements(field) {
+			g.writeElemCopy(v, indexed+"["+i+"]", field)
+			g.Line("return ", v, ", true")
+		} else {
+			g.Line("return ", indexed, "[", i, "], true")
+		}
+		g.closeNilCheck(depth, "return ", v, ", false")
+		g.Line("}")
+		g.Line()
+	}
+
+	g.methodDoc(field.Deprecated, fieldName+"Len returns the length of the "+field.Name+" field.")
+	g.openGetter(structName, fieldName, "Len() int")
+	depth := g.openNilCheck(field, field.IsPointer, "")
+	g.Line("return len(", value, ")")
+	g.closeNilCheck(depth, "return 0")
+	g.Line("}")
+	g.Line()
+}
@@ -0,0 +1,14 @@
+package generator
+
+import "github.com/renxzen/go-getters/pkg/types"
+
+// atomicResult returns the value loaded from a sync/atomic field read through
+// expr, since returning the atomic itself would copy it.
+func (g *Generator) atomicResult(field types.FieldInfo, expr string, nilCheck []string) getterResult {
+	return getterResult{
+		Type:       field.AtomicType,
+		Conditions: nilCheck,
+		Zero:       zeroValue(field.AtomicType),
+		Return:     func() { g.Line("return ", expr, ".Load()") },
+	}
+}
@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// copyMode returns the copy mode for a field: the overriding one if set, else
+// the one of the field's own tag, else the generator default.
+func (g *Generator) copyMode(field types.FieldInfo) types.CopyMode {
+	if g.opts.Overrides.CopyMode != "" {
+		return g.opts.Overrides.CopyMode
+	}
+
+	if field.CopyMode != "" {
+		return field.CopyMode
+	}
+
+	return g.opts.CopyMode
+}
+
+// copies reports whether the getter of a field returns a copy of its
+// collection.
+func (g *Generator) copies(field types.FieldInfo) bool {
+	return g.copyMode(field) != types.CopyNone && field.IsCollection()
+}
+
+// copyResult returns a copy of the slice or map field read through expr.
+// Pointers to slices are dereferenced like in regular getters, while pointers
+// to maps return a pointer to the copy.
+func (g *Generator) copyResult(field types.FieldInfo, expr string, nilCheck []string) getterResult {
+	returnType, value := field.Type, expr
+	if field.IsPointer {
+		if field.IsSlice {
+			returnType = field.UnderlyingType
+		}
+		value = "*" + value
+	}
+
+	deep := g.copyMode(field) == types.CopyDeep && needsDeepCopy(field.Elem)
+	return getterResult{
+		Type:       returnType,
+		Conditions: nilCheck,
+		Zero:       field.GetZerovalue(),
+		Return: func() {
+			if !deep && !(field.IsPointer && field.IsMap) {
+				g.Line("return ", g.cloneFunc(field), "(", value, ")")
+				return
+			}
+
+			c := g.local("c")
+			g.writeCopy(c, value, field, deep)
+			if field.IsPointer && field.IsMap {
+				g.Line("return &", c)
+			} else {
+				g.Line("return ", c)
+			}
+		},
+	}
+}
+
+// writeCopy writes the declaration of c, a copy of the collection of field
+// read through expr, whose nested slices and maps are copied as well if deep.
+func (g *Generator) writeCopy(c, expr string, field types.FieldInfo, deep bool) {
+	g.Line(c, " := ", g.cloneFunc(field), "(", expr, ")")
+	if deep {
+		g.writeDeepCopy(c, field, 0)
+	}
+}
+
+// writeDeepCopy writes loops replacing every slice or map nested in the
+// collection expr with a copy of its own. Nested pointers are left untouched.
+func (g *Generator) writeDeepCopy(expr string, collection types.FieldInfo, depth int) {
+	elem := collection.Elem
+	if !needsDeepCopy(elem) {
+		return
+	}
+
+	index := g.local(fmt.Sprintf("i%d", depth))
+	if collection.IsMap {
+		index = g.local(fmt.Sprintf("k%d", depth))
+	}
+	item := expr + "[" + index + "]"
+
+	g.Line("for ", index, " := range ", expr, " {")
+	g.Line(item, " = ", g.cloneFunc(*elem), "(", item, ")")
+	g.writeDeepCopy(item, *elem, depth+1)
+	g.Line("}")
+}
+
+// copiesElements reports whether the accessors of a field return copies of its
+// elements, which hold slices or maps its deep copy mode doesn't share.
+func (g *Generator) copiesElements(field types.FieldInfo) bool {
+	return g.copyMode(field) == types.CopyDeep && needsDeepCopy(field.Elem)
+}
+
+// writeElemCopy writes the assignment to v of a deep copy of the element of
+// collection read through expr.
+func (g *Generator) writeElemCopy(v, expr string, collection types.FieldInfo) {
+	g.Line(v, " = ", g.cloneFunc(*collection.Elem), "(", expr, ")")
+	g.writeDeepCopy(v, *collection.Elem, 0)
+}
+
+// cloneFunc returns the function cloning the collection held by field and
+// registers the import it needs.
+func (g *Generator) cloneFunc(field types.FieldInfo) string {
+	if field.IsMap {
+		return g.importPackage("maps") + ".Clone"
+	}
+
+	return g.importPackage("slices") + ".Clone"
+}
+
+// needsDeepCopy reports whether elements of this type hold a slice or map that
+// must be copied as well.
+func needsDeepCopy(elem *types.FieldInfo) bool {
+	return elem != nil && !elem.IsPointer && elem.IsCollection()
+}
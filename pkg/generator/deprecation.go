@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// deprecationOnce returns the name of the package-level sync.Once guarding the
+// deprecation warning of a field.
+func deprecationOnce(structName string, field types.FieldInfo) string {
+	return "deprecated" + structName + field.Name + "Once"
+}
+
+// declareDeprecationOnce declares the sync.Once used by warnDeprecated, when a
+// deprecation hook is configured, the field is deprecated and it is not
+// declared yet, such as by a path getter reading the field of another struct.
+func (g *Generator) declareDeprecationOnce(structName string, field types.FieldInfo) {
+	once := deprecationOnce(structName, field)
+	if g.opts.DeprecationHook == "" || field.Deprecated == "" || g.onces[once] {
+		return
+	}
+	g.onces[once] = true
+
+	g.Line("var ", once, " ", g.importPackage("sync"), ".Once")
+	g.Line()
+}
+
+// warnDeprecated writes a call to the deprecation hook, made the first time a
+// method of a deprecated field is called.
+func (g *Generator) warnDeprecated(structName string, field types.FieldInfo) {
+	if g.opts.DeprecationHook == "" || field.Deprecated == "" {
+		return
+	}
+
+	notice := strings.Join(strings.Fields(strings.TrimPrefix(field.Deprecated, types.DeprecatedPrefix)), " ")
+	message := structName + "." + field.Name + " is deprecated: " + notice
+	g.Line(deprecationOnce(structName, field), ".Do(func() { ", g.qualify(g.opts.DeprecationHook), "(", strconv.Quote(message), ") })")
+}
+
+// hookPackage returns the name of the package of the deprecation hook, or an
+// empty string if there is none.
+func (g *Generator) hookPackage() string {
+	path, _ := types.SplitQualifiedType(g.opts.DeprecationHook)
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// doc writes a doc comment made of the given lines when doc comments are
+// enabled. Empty lines separate paragraphs.
+func (g *Generator) doc(lines ...string) {
+	if !g.opts.DocComments {
+		return
+	}
+
+	for _, line := range lines {
+		g.comment(line)
+	}
+}
+
+// comment writes a line of a doc comment. When generating functions in
+// another package, the lines are kept until the function is opened, so that
+// they can be renamed after it.
+func (g *Generator) comment(line string) {
+	text := "//"
+	if line != "" {
+		text += " " + line
+	}
+
+	if g.external() {
+		g.pendingDoc = append(g.pendingDoc, text)
+		return
+	}
+
+	g.Line(text)
+}
+
+// methodDoc writes the doc comment of a method generated for a field: the
+// given lines when doc comments are enabled, followed by the field's
+// deprecation notice, if any, which is written even when they are disabled
+// so that tools flag uses of the method.
+func (g *Generator) methodDoc(deprecated string, lines ...string) {
+	g.doc(lines...)
+	if deprecated == "" {
+		return
+	}
+
+	if g.opts.DocComments && len(lines) > 0 {
+		g.comment("")
+	}
+	for _, line := range strings.Split(deprecated, "\n") {
+		g.comment(line)
+	}
+}
+
+// getterDoc writes the doc comment of a getter, derived from its field's comment.
+func (g *Generator) getterDoc(getterName string, field types.FieldInfo) {
+	g.methodDoc(field.Deprecated, getterDocLines(getterName, field)...)
+}
+
+// getterDocLines returns the doc comment of a getter. A field comment such as
+// "Email is the verified address" becomes "GetEmail returns the verified
+// address.". Other comments are kept as a paragraph following a generic
+// summary, and so are the paragraphs after the first one, except for the
+// deprecation notice written by methodDoc.
+func getterDocLines(getterName string, field types.FieldInfo) []string {
+	summary := getterName + " returns the " + field.Name + " field."
+	if field.Doc == "" {
+		return []string{summary}
+	}
+
+	paragraphs := strings.Split(field.Doc, "\n\n")
+	first := strings.Join(strings.Fields(paragraphs[0]), " ")
+
+	var lines []string
+	if description, ok := describedAs(field.Name, first); ok {
+		lines = append(lines, getterName+" returns "+withPeriod(description))
+		paragraphs = paragraphs[1:]
+	} else {
+		lines = append(lines, summary)
+	}
+
+	for _, paragraph := range paragraphs {
+		if strings.HasPrefix(paragraph, types.DeprecatedPrefix) {
+			continue
+		}
+
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(paragraph, "\n")...)
+	}
+
+	return lines
+}
+
+// describedAs returns the description following "<name> is" or "<name> are"
+// in a comment, when it is a noun phrase that a getter returns.
+func describedAs(name, comment string) (string, bool) {
+	for _, verb := range []string{" is ", " are "} {
+		description, ok := strings.CutPrefix(comment, name+verb)
+		if !ok {
+			continue
+		}
+
+		for _, article := range []string{"the ", "a ", "an "} {
+			if strings.HasPrefix(description, article) {
+				return description, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// withPeriod ends a sentence with a period if it has no final punctuation.
+func withPeriod(sentence string) string {
+	if strings.HasSuffix(sentence, ".") || strings.HasSuffix(sentence, "!") || strings.HasSuffix(sentence, "?") {
+		return sentence
+	}
+
+	return sentence + "."
+}
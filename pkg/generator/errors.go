@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/strutils"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// maxSuggestions is the number of struct names suggested for a missing one.
+const maxSuggestions = 3
+
+// StructError reports a requested struct that the package doesn't declare,
+// either because no type has that name or because the type isn't a struct.
+type StructError struct {
+	Name string
+	// Type is the named type declared with the name, nil if there is none.
+	Type *types.TypeInfo
+	// Suggestions lists the structs of the package with a similar name,
+	// closest first.
+	Suggestions []*types.StructInfo
+}
+
+func (e *StructError) Error() string {
+	var msg strings.Builder
+	if e.Type != nil {
+		fmt.Fprintf(&msg, "%s is not a struct: it is %s (%s)", e.Name, e.Type.Kind, position(e.Type.Position))
+	} else {
+		fmt.Fprintf(&msg, "struct %s not found", e.Name)
+	}
+
+	if len(e.Suggestions) > 0 {
+		suggestions := make([]string, len(e.Suggestions))
+		for i, suggestion := range e.Suggestions {
+			suggestions[i] = fmt.Sprintf("%s (%s)", suggestion.Name, position(suggestion.Position))
+		}
+
+		if e.Type != nil && e.Type.Target == e.Suggestions[0].Name {
+			fmt.Fprintf(&msg, "; use %s instead", suggestions[0])
+		} else {
+			fmt.Fprintf(&msg, "; did you mean %s?", strings.Join(suggestions, " or "))
+		}
+	}
+
+	return msg.String()
+}
+
+// checkStructs returns the errors of all the requested structs the package
+// doesn't declare, joined, and records them as diagnostics.
+func (g *Generator) checkStructs(structNames []string, parseResult *types.ParseResult) error {
+	var errs []error
+	for _, name := range structNames {
+		if _, exists := parseResult.Structs[name]; !exists {
+			err := newStructError(name, parseResult)
+			g.diags.Errorf(token.Position{}, diag.MissingStruct, "%s", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// newStructError describes why the named struct can't be found, suggesting
+// the struct an alias refers to, or else the structs with the closest names.
+func newStructError(name string, parseResult *types.ParseResult) *StructError {
+	err := &StructError{
+		Name: name,
+		Type: parseResult.Types[name],
+	}
+
+	if err.Type != nil && err.Type.Target != "" {
+		if target, exists := parseResult.Structs[err.Type.Target]; exists {
+			err.Suggestions = []*types.StructInfo{target}
+			return err
+		}
+	}
+
+	// Names differing by their case only are the closest
+	lower := strings.ToLower(name)
+	maxDistance := max(1, len(name)/3)
+	distances := make(map[string]int)
+	for _, candidate := range slices.Sorted(maps.Keys(parseResult.Structs)) {
+		distance := strutils.Distance(lower, strings.ToLower(candidate))
+		if distance <= maxDistance {
+			distances[candidate] = distance
+		}
+	}
+
+	candidates := slices.SortedFunc(maps.Keys(distances), func(a, b string) int {
+		if distances[a] != distances[b] {
+			return distances[a] - distances[b]
+		}
+		return strings.Compare(a, b)
+	})
+	for _, candidate := range candidates[:min(len(candidates), maxSuggestions)] {
+		err.Suggestions = append(err.Suggestions, parseResult.Structs[candidate])
+	}
+
+	return err
+}
+
+// position formats a position as file:line:column.
+func position(pos token.Position) string {
+	if !pos.IsValid() {
+		return "unknown position"
+	}
+
+	return pos.String()
+}
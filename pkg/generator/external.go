@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"path"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// external reports whether the code is generated in another package than the
+// source one, as functions rather than methods.
+func (g *Generator) external() bool {
+	return g.opts.OutputPackage != ""
+}
+
+// useSourcePackage prepares the generation of functions in another package
+// than the source one: the source package is imported, and the requested
+// structs are replaced by copies whose field types refer to the types of the
+// source package through its name. It returns the structs, by name.
+func (g *Generator) useSourcePackage(structNames []string, parseResult *types.ParseResult) map[string]*types.StructInfo {
+	g.source = parseResult.PackageName
+	g.functions = make(map[string]bool)
+	g.imports[g.opts.SourcePath] = &types.ImportInfo{
+		Alias:     g.source,
+		Path:      g.opts.SourcePath,
+		IsAliased: path.Base(g.opts.SourcePath) != g.source,
+	}
+
+	local := make(map[string]bool)
+	for name := range parseResult.Structs {
+		local[name] = true
+	}
+	for name := range parseResult.Types {
+		local[name] = true
+	}
+
+	structs := make(map[string]*types.StructInfo, len(structNames))
+	for _, name := range structNames {
+		structs[name] = g.qualifyStruct(parseResult.Structs[name], local)
+	}
+
+	return structs
+}
+
+// qualifyStruct returns a copy of the struct whose field types refer to the
+// types of the source package, listed by local, through its name. Fields of
+// unexported types can't be used by another package, so they are skipped, and
+// so are unexported structs.
+func (g *Generator) qualifyStruct(structInfo *types.StructInfo, local map[string]bool) *types.StructInfo {
+	qualified := *structInfo
+	qualified.Fields = make([]types.FieldInfo, len(structInfo.Fields))
+
+	if !token.IsExported(structInfo.Name) {
+		g.diags.Errorf(structInfo.Position, diag.OutputPackage, "%s: unexported structs can't be used by package %s", structInfo.Name, g.opts.OutputPackage)
+	}
+	if lock := structInfo.LockField(); lock != nil && !lock.IsExported {
+		g.diags.Errorf(lock.Position, diag.OutputPackage, "%s: its unexported lock %s can't be held by package %s", structInfo.Name, lock.Name, g.opts.OutputPackage)
+	}
+
+	for i, field := range structInfo.Fields {
+		exported := g.qualifyField(&field, local)
+		if !exported && hasGetter(field) {
+			g.diags.Warnf(field.Position, diag.OutputPackage, "%s.%s: skipped, since its type %s refers to unexported types", structInfo.Name, field.Name, structInfo.Fields[i].Type)
+			field.Skip = true
+		}
+		qualified.Fields[i] = field
+	}
+
+	return &qualified
+}
+
+// qualifyField qualifies the types of a field, and of its key and element
+// types, in place. It reports whether they only refer to exported types.
+func (g *Generator) qualifyField(field *types.FieldInfo, local map[string]bool) bool {
+	exported := true
+	for _, typeExpr := range []*string{&field.Type, &field.UnderlyingType, &field.AtomicType} {
+		var ok bool
+		*typeExpr, ok = g.qualifyType(*typeExpr, local)
+		exported = exported && ok
+	}
+
+	if field.Key != nil {
+		key := *field.Key
+		exported = g.qualifyField(&key, local) && exported
+		field.Key = &key
+	}
+	if field.Elem != nil {
+		elem := *field.Elem
+		exported = g.qualifyField(&elem, local) && exported
+		field.Elem = &elem
+	}
+
+	return exported
+}
+
+// qualifyType qualifies the names of the types of the source package listed
+// by local in a type expression, such as "[]Example" becoming
+// "[]models.Example". It reports whether they are all exported.
+func (g *Generator) qualifyType(typeExpr string, local map[string]bool) (string, bool) {
+	if typeExpr == "" {
+		return "", true
+	}
+
+	expr, err := parser.ParseExpr(typeExpr)
+	if err != nil {
+		return typeExpr, true
+	}
+
+	exported := true
+	var visit func(ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			// Types of other packages are already qualified
+			return false
+		case *ast.Field:
+			// Parameter and field names are not types
+			ast.Inspect(n.Type, visit)
+			return false
+		case *ast.Ident:
+			if local[n.Name] {
+				exported = exported && token.IsExported(n.Name)
+				n.Name = g.source + "." + n.Name
+			}
+		}
+		return true
+	}
+	ast.Inspect(expr, visit)
+
+	return gotypes.ExprString(expr), exported
+}
+
+// useExternalOptions disables, for the struct being generated, the options
+// producing declarations that can't be generated in another package, and
+// reports them.
+func (g *Generator) useExternalOptions(structInfo *types.StructInfo) {
+	unsupported := []struct {
+		enabled *bool
+		name    string
+	}{
+		{&g.opts.Interfaces, "interfaces"},
+		{&g.opts.FieldMetadata, "field metadata"},
+		{&g.opts.FieldAccess, "field access methods"},
+	}
+	for _, option := range unsupported {
+		if *option.enabled {
+			g.diags.Warnf(structInfo.Position, diag.OutputPackage, "%s: %s are not generated in package %s", structInfo.Name, option.name, g.opts.OutputPackage)
+			*option.enabled = false
+		}
+	}
+
+	if g.opts.PathDepth > 0 {
+		g.diags.Warnf(structInfo.Position, diag.OutputPackage, "%s: path getters are not generated in package %s", structInfo.Name, g.opts.OutputPackage)
+		g.opts.PathDepth = 0
+	}
+}
+
+// openFunction writes the opening line of the function replacing a method of
+// the struct in another package, taking the receiver as its first parameter.
+// The doc comment written for the method is renamed after the function.
+func (g *Generator) openFunction(structName, signature string, value bool) {
+	method, params, _ := strings.Cut(signature, "(")
+	name := g.functionName(structName, method)
+
+	if g.functions[name] {
+		g.diags.Errorf(g.pos, diag.NameConflict, "%s: function %s is generated twice", structName, name)
+	}
+	g.functions[name] = true
+
+	for i, line := range g.pendingDoc {
+		if i == 0 {
+			if rest, ok := strings.CutPrefix(line, "// "+method+" "); ok {
+				line = "// " + name + " " + rest
+			}
+		}
+		g.Line(line)
+	}
+	g.pendingDoc = g.pendingDoc[:0]
+
+	receiverType := g.source + "." + structName
+	if !value {
+		receiverType = "*" + receiverType
+	}
+	if !strings.HasPrefix(params, ")") {
+		params = ", " + params
+	}
+	g.Line("func ", name, "(", g.recv, " ", receiverType, params, " {")
+}
+
+// functionName returns the name of the function replacing a method of the
+// struct in another package: getters are named after the struct and the
+// field, so that GetName becomes UserName, setters become SetUserName, and
+// other methods are prefixed with the struct name, like UserAllItems.
+func (g *Generator) functionName(structName, method string) string {
+	for _, field := range g.current.Fields {
+		switch method {
+		case g.getterName(field):
+			return structName + field.MethodName()
+		case g.setterName(field):
+			return g.opts.SetterPrefix + structName + field.MethodName()
+		}
+	}
+
+	return structName + method
+}
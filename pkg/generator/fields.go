@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"strconv"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// generateFieldMetadata generates a constant holding the name of each field
+// with a getter, and a table describing these fields.
+func (g *Generator) generateFieldMetadata(structInfo *types.StructInfo) {
+	var fields []types.FieldInfo
+	for _, field := range structInfo.Fields {
+		if hasGetter(field) {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	g.Line("// Names of the fields of ", structInfo.Name, ".")
+	g.Line("const (")
+	for _, field := range fields {
+		g.Line(fieldConstant(structInfo.Name, field), " = ", strconv.Quote(field.Name))
+	}
+	g.Line(")")
+	g.Line()
+
+	g.Line("// ", structInfo.Name, "Fields describes the fields of ", structInfo.Name, " with a getter.")
+	g.Line("// JSON holds the name given by the json tag, empty if there is none.")
+	g.Line("var ", structInfo.Name, "Fields = []struct {")
+	g.Line("Name string")
+	g.Line("Type string")
+	g.Line("JSON string")
+	g.Line("Getter func(*", structInfo.Name, ") any")
+	g.Line("}{")
+	for _, field := range fields {
+		g.Line("{")
+		g.Line("Name: ", fieldConstant(structInfo.Name, field), ",")
+		g.Line("Type: ", strconv.Quote(field.Type), ",")
+		g.Line("JSON: ", strconv.Quote(field.JSONName()), ",")
+		g.Line("Getter: func(x *", structInfo.Name, ") any { return x.", g.getterName(field), "() },")
+		g.Line("},")
+	}
+	g.Line("}")
+	g.Line()
+}
+
+// fieldConstant returns the name of the constant holding the name of a field.
+func fieldConstant(structName string, field types.FieldInfo) string {
+	return structName + "Field" + field.Name
+}
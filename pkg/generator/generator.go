@@ -3,22 +3,56 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
-	"github.com/renxzen/go-getters/pkg/strutils"
+	"github.com/renxzen/go-getters/pkg/diag"
 	"github.com/renxzen/go-getters/pkg/types"
 )
 
 // Generator handles code generation for getter methods.
 type Generator struct {
-	buf *bytes.Buffer
+	buf     *bytes.Buffer
+	opts    Options
+	imports map[string]*types.ImportInfo // Imports required by the generated code itself, by path
+	lock    *types.FieldInfo             // Mutex guarding the struct being generated, if any
+	getters []string                     // Signatures of the read-only methods of the struct being generated
+	value   bool                         // Whether the getters of the struct being generated use a value receiver
+	recv    string                       // Name of the receiver of the struct being generated
+	current *types.StructInfo            // Struct being generated
+	pos     token.Position               // Position blamed for issues with the method being generated
+	methods map[string]bool              // Names of the methods generated for the struct being generated
+	onces   map[string]bool              // Names of the sync.Once variables declared for deprecation warnings
+
+	source     string          // Name the source package is imported with, when generating functions in another package
+	functions  map[string]bool // Names of the functions generated in another package
+	pendingDoc []string        // Doc comment lines of the function about to be opened in another package
+
+	diags diag.List // Issues found while generating
+
+	sourceImports map[string]*types.ImportInfo // Imports of the source package, by alias
+	structs       map[string]*types.StructInfo // Structs of the source package, by name
 }
 
 // New creates a new Generator instance.
-func New() *Generator {
+func New(opts ...Option) *Generator {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return &Generator{
-		buf: &bytes.Buffer{},
+		buf:     &bytes.Buffer{},
+		opts:    options,
+		imports: make(map[string]*types.ImportInfo),
+		onces:   make(map[string]bool),
 	}
 }
 
@@ -31,26 +65,85 @@ func (g *Generator) Line(values ...any) {
 	fmt.Fprintln(g.buf)
 }
 
-// GenerateGetters generates getter methods for the specified structs.
+// Diagnostics returns the issues found by GenerateGetters: errors preventing
+// generation, warnings about options that could not be honored or code that
+// may not compile, and notes about the choices made.
+func (g *Generator) Diagnostics() []diag.Diagnostic {
+	return g.diags.All()
+}
+
+// Warnings returns the messages of the warnings found by GenerateGetters.
+func (g *Generator) Warnings() []string {
+	var warnings []string
+	for _, d := range g.diags.Filter(diag.SeverityWarning) {
+		warnings = append(warnings, d.Message)
+	}
+
+	return warnings
+}
+
+// GenerateGetters generates getter methods for the specified structs, or the
+// functions replacing them when an output package is set.
 func (g *Generator) GenerateGetters(structNames []string, parseResult *types.ParseResult) ([]byte, error) {
 	packageName := parseResult.PackageName
 	structs := parseResult.Structs
+	g.sourceImports = parseResult.Imports
+	g.structs = structs
+
+	// Check that all requested structs exist, reporting every missing one
+	if err := g.checkStructs(structNames, parseResult); err != nil {
+		return nil, err
+	}
+
+	if _, err := g.interfaceNamer(); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := g.template()
+	if err != nil {
+		return nil, err
+	}
+
+	if g.external() {
+		packageName = g.opts.OutputPackage
+		structs = g.useSourcePackage(structNames, parseResult)
+	}
+
+	data := TemplateData{
+		PackageName: packageName,
+		Structs:     make([]*types.StructInfo, 0, len(structNames)),
+	}
+	for _, structName := range structNames {
+		data.Structs = append(data.Structs, structs[structName])
+	}
+
+	// Generate getters for each requested struct. The body is generated first
+	// since it determines which imports the generated code itself needs.
+	if err := tmpl.Execute(g.buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	if err := g.diags.Err(); err != nil {
+		return nil, err
+	}
+	body := g.buf.Bytes()
+	g.buf = &bytes.Buffer{}
+
+	usedPackages, err := referencedPackages(body)
+	if err != nil {
+		return nil, err
+	}
 
 	// Write package declaration and header
-	g.Line("// Code generated by go-getters. DO NOT EDIT.")
+	g.Line(types.GeneratedHeader)
 	g.Line()
 	g.Line("package ", packageName)
 	g.Line()
 
-	// Check if all requested structs exist
-	for _, structName := range structNames {
-		if _, exists := structs[structName]; !exists {
-			return nil, fmt.Errorf("struct %s not found", structName)
-		}
-	}
-
 	// Collect required imports
 	requiredImports := g.collectRequiredImports(structs, structNames, parseResult.Imports)
+	requiredImports = slices.DeleteFunc(requiredImports, func(imp *types.ImportInfo) bool {
+		return !usedPackages[imp.Alias]
+	})
 	if len(requiredImports) > 0 {
 		g.Line("import (")
 		for _, imp := range requiredImports {
@@ -63,66 +156,315 @@ func (g *Generator) GenerateGetters(structNames []string, parseResult *types.Par
 		g.Line(")")
 		g.Line()
 	}
+	g.buf.Write(body)
 
-	// Generate getters for each requested struct
-	for _, structName := range structNames {
-		structInfo := structs[structName]
-		g.generateStructGetters(structInfo)
+	return format.Source(g.buf.Bytes())
+}
+
+// generateStruct generates the built-in methods and declarations of a struct,
+// with the options given for it.
+func (g *Generator) generateStruct(structInfo *types.StructInfo) error {
+	defer g.useStructOptions(structInfo.Name)()
+	if g.external() {
+		g.useExternalOptions(structInfo)
 	}
 
-	return format.Source(g.buf.Bytes())
+	g.generateStructGetters(structInfo)
+
+	interfaceName, err := g.interfaceNamer()
+	if err != nil {
+		return err
+	}
+	if interfaceName != nil {
+		if err := g.generateInterface(structInfo, interfaceName); err != nil {
+			return err
+		}
+	}
+
+	if g.opts.FieldMetadata {
+		g.generateFieldMetadata(structInfo)
+	}
+
+	return nil
+}
+
+// useStructOptions applies the options given for a struct on top of the
+// others, and returns the function restoring them.
+func (g *Generator) useStructOptions(structName string) (restore func()) {
+	opts := g.opts
+	restore = func() { g.opts = opts }
+
+	structOpts := g.opts.Structs[structName]
+	if len(structOpts) == 0 {
+		return restore
+	}
+
+	// Options appending to slices must not write to the shared ones
+	g.opts.NullableTypes = slices.Clip(g.opts.NullableTypes)
+	g.opts.TemplateFiles = slices.Clip(g.opts.TemplateFiles)
+	g.opts.ValueReceivers = slices.Clip(g.opts.ValueReceivers)
+	for _, opt := range structOpts {
+		opt(&g.opts)
+	}
+
+	return restore
+}
+
+// referencedPackages returns the names of the packages referenced by the
+// generated declarations, so that imports left unused by templates are dropped.
+func referencedPackages(body []byte) (map[string]bool, error) {
+	src := append([]byte("package generated\n"), body...)
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %w", err)
+	}
+
+	packages := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				packages[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	return packages, nil
 }
 
 // generateStructGetters generates getter methods for a single struct.
 func (g *Generator) generateStructGetters(structInfo *types.StructInfo) {
+	g.lock = structInfo.LockField()
+	g.getters = g.getters[:0]
+	g.current = structInfo
+	g.pos = structInfo.Position
+	g.methods = make(map[string]bool)
+	g.value = g.valueReceiver(structInfo)
+	g.recv = g.receiverName(structInfo)
+
 	for _, field := range structInfo.Fields {
-		// Skip unexported fields
-		if !field.IsExported {
+		if isAccessible(field) && refusesCopy(field) {
+			g.diags.Warnf(field.Position, diag.NoCopy, "%s.%s: no methods generated, since its type %s holds a value that must not be copied, like a mutex", structInfo.Name, field.Name, field.Type)
+		}
+		if !hasGetter(field) {
 			continue
 		}
 
+		g.pos = field.Position
+		if field.Unsupported != "" {
+			g.diags.Warnf(field.Position, diag.UnsupportedType, "%s.%s: type %s is not supported, the generated methods use any instead", structInfo.Name, field.Name, field.Unsupported)
+		}
+
+		g.declareDeprecationOnce(structInfo.Name, field)
 		g.generateFieldGetter(structInfo.Name, field)
+
+		if g.opts.Iterators && field.IsCollection() {
+			g.generateFieldIterator(structInfo.Name, field)
+		}
+
+		if g.opts.Accessors && field.IsCollection() {
+			g.generateFieldAccessors(structInfo.Name, field)
+		}
+
+		if g.opts.Setters {
+			g.generateFieldSetter(structInfo.Name, field)
+		}
+	}
+
+	g.pos = structInfo.Position
+	if g.opts.PathDepth > 0 {
+		g.generatePathGetters(structInfo)
+	}
+
+	if g.opts.FieldAccess {
+		g.generateFieldAccess(structInfo)
+	}
+}
+
+// openMethod writes the opening line of a method on the struct, with a
+// pointer receiver.
+func (g *Generator) openMethod(structName string, signature ...any) {
+	if g.external() {
+		g.openFunction(structName, fmt.Sprint(signature...), false)
+		return
+	}
+
+	g.checkMethodName(structName, fmt.Sprint(signature...))
+	g.Line("func (", g.recv, " *", structName, ") ", fmt.Sprint(signature...), " {")
+}
+
+// openGetter writes the opening line of a read-only method on the struct and
+// records its signature for the struct's interface. It has a value receiver
+// when the struct's getters use one.
+func (g *Generator) openGetter(structName string, signature ...any) {
+	g.getters = append(g.getters, fmt.Sprint(signature...))
+	if g.external() {
+		g.openFunction(structName, fmt.Sprint(signature...), g.value)
+		return
+	}
+
+	if g.value {
+		g.checkMethodName(structName, fmt.Sprint(signature...))
+		g.Line("func (", g.recv, " ", structName, ") ", fmt.Sprint(signature...), " {")
+		return
+	}
+
+	g.openMethod(structName, signature...)
+}
+
+// checkMethodName reports an error if the method with the signature is named
+// like another method of the struct or one of its fields, which doesn't
+// compile.
+func (g *Generator) checkMethodName(structName, signature string) {
+	name, _, _ := strings.Cut(signature, "(")
+	if g.methods[name] {
+		g.diags.Errorf(g.pos, diag.NameConflict, "%s: method %s is generated twice", structName, name)
+		return
+	}
+	g.methods[name] = true
+
+	for _, method := range g.current.Methods {
+		if method.Name == name {
+			g.diags.Errorf(g.pos, diag.NameConflict, "%s: generated method %s conflicts with the method declared at %s", structName, name, position(method.Position))
+			return
+		}
+	}
+
+	for _, field := range g.current.Fields {
+		if field.Name == name {
+			g.diags.Errorf(g.pos, diag.NameConflict, "%s: generated method %s conflicts with the field declared at %s", structName, name, position(field.Position))
+			return
+		}
+	}
+}
+
+// hasGetter reports whether methods are generated for the field. Unexported
+// and embedded fields are skipped, as well as fields skipped by their tag, the
+// struct's lock and values that must not be copied, unless they are
+// sync/atomic types.
+func hasGetter(field types.FieldInfo) bool {
+	return isAccessible(field) && !refusesCopy(field)
+}
+
+// isAccessible reports whether the field could have methods: it is exported,
+// not embedded, not skipped by its tag and not the struct's lock.
+func isAccessible(field types.FieldInfo) bool {
+	return field.IsExported && !field.IsEmbedded && !field.Skip && field.Lock == types.LockNone
+}
+
+// refusesCopy reports whether the field holds a value that must not be
+// copied, such as a mutex, and that isn't read through a sync/atomic type.
+func refusesCopy(field types.FieldInfo) bool {
+	return field.NoCopy && field.AtomicType == ""
+}
+
+// getterName returns the name of the getter generated for a field.
+func (g *Generator) getterName(field types.FieldInfo) string {
+	return g.opts.GetterPrefix + field.MethodName()
+}
+
+// dereferences reports whether the getter of a pointer field returns the
+// value it points to rather than the pointer.
+func (g *Generator) dereferences(field types.FieldInfo) bool {
+	if g.opts.Deref == types.DerefNever {
+		return false
+	}
+
+	return field.IsPointer && (field.IsPrimitive() || field.IsSlice)
+}
+
+// getterZero returns the value returned by the getter of a field when the
+// receiver, or the pointer it dereferences, is nil.
+func (g *Generator) getterZero(field types.FieldInfo) string {
+	if field.IsPointer && !g.dereferences(field) {
+		return "nil"
+	}
+
+	return field.GetZerovalue()
+}
+
+// getterResult describes the value returned by the getter of a field.
+type getterResult struct {
+	Type       string   // Type returned by the getter
+	Conditions []string // Conditions for reading the value, besides the receiver not being nil
+	Zero       string   // Value returned when a condition doesn't hold
+	Return     func()   // Writes the statements returning the value
+}
+
+// getterResult returns the value returned by the getter of a field read
+// through expr, so that path getters return the same value as the field's own
+// getter: sync/atomic fields are loaded, nullable wrappers unwrapped,
+// collections copied according to their copy mode and pointers dereferenced
+// according to the dereference policy.
+func (g *Generator) getterResult(field types.FieldInfo, expr string) getterResult {
+	var nilCheck []string
+	if field.IsPointer {
+		nilCheck = []string{expr + " != nil"}
+	}
+
+	if field.AtomicType != "" {
+		return g.atomicResult(field, expr, nilCheck)
+	}
+
+	if nullable := g.nullableType(field); nullable != nil {
+		return g.nullableResult(field, expr, nilCheck, nullable)
+	}
+
+	if g.copies(field) {
+		return g.copyResult(field, expr, nilCheck)
+	}
+
+	// For pointer fields to primitives and specific types, return the dereferenced type
+	if g.dereferences(field) {
+		return getterResult{
+			Type:       field.UnderlyingType,
+			Conditions: nilCheck,
+			Zero:       g.getterZero(field),
+			Return:     func() { g.Line("return *", expr) },
+		}
+	}
+
+	return getterResult{
+		Type:   field.Type,
+		Zero:   g.getterZero(field),
+		Return: func() { g.Line("return ", expr) },
 	}
 }
 
 // generateFieldGetter generates a getter method for a single field.
 func (g *Generator) generateFieldGetter(structName string, field types.FieldInfo) {
-	// TODO: make the prefix configurable
-	getterPrefix := "Get"
-	getterName := getterPrefix + strutils.Capitalize(field.Name)
-	zeroValue := field.GetZerovalue()
+	getterName := g.getterName(field)
+	result := g.getterResult(field, g.recv+"."+field.Name)
+	nullable := g.nullableType(field)
 
-	// For pointer fields to primitives and specific types, return the dereferenced type
-	if field.IsPointer && (field.IsPrimitive() || field.IsSlice) {
-		g.Line("func (x *", structName, ") ", getterName, "() ", field.UnderlyingType, " {")
-		g.Line("if x != nil && x.", field.Name, " != nil {")
-		g.Line("return *x.", field.Name)
-		g.Line("}")
-		g.Line("return ", zeroValue)
-	} else {
-		g.Line("func (x *", structName, ") ", getterName, "() ", field.Type, " {")
-		g.Line("if x != nil {")
-		g.Line("return x.", field.Name)
-		g.Line("}")
-		g.Line("return ", zeroValue)
+	if g.dereferences(field) && field.AtomicType == "" && nullable == nil && !g.copies(field) {
+		g.diags.Infof(field.Position, diag.DerefAmbiguity, "%s.%s returns %s both when %s is nil and when it points to %s; use -deref=never to tell them apart", structName, getterName, result.Zero, field.Name, result.Zero)
 	}
 
+	g.getterDoc(getterName, field)
+	g.openGetter(structName, getterName, "() ", result.Type)
+	g.warnDeprecated(structName, field)
+	depth := g.openNilCheck(field, false, joinConditions(result.Conditions))
+	result.Return()
+	g.closeNilCheck(depth, "return ", result.Zero)
 	g.Line("}")
 	g.Line()
+
+	if nullable != nil {
+		g.generateNullableHas(structName, field, nullable)
+	}
 }
 
-// collectRequiredImports collects all import paths needed for the specified structs
+// collectRequiredImports collects all import paths needed for the specified
+// structs. Those left unused by the generated code, such as the package of a
+// nullable wrapper whose getter unwraps it, are dropped by the caller.
 func (g *Generator) collectRequiredImports(structs map[string]*types.StructInfo, structNames []string, importsMap map[string]*types.ImportInfo) []*types.ImportInfo {
 	importSet := make(map[string]bool)
 
 	for _, structName := range structNames {
 		structInfo := structs[structName]
 		for _, field := range structInfo.Fields {
-			if !field.IsExported {
-				continue
-			}
-
-			if len(field.RequiredImports) == 0 {
+			if !hasGetter(field) {
 				continue
 			}
 
@@ -135,7 +477,7 @@ func (g *Generator) collectRequiredImports(structs map[string]*types.StructInfo,
 	}
 
 	// Convert set to sorted slice to ensure deterministic output
-	imports := make([]*types.ImportInfo, 0, len(importSet))
+	imports := make([]*types.ImportInfo, 0, len(importSet)+len(g.imports))
 	for imp := range importSet {
 		info, exists := importsMap[imp]
 		if !exists {
@@ -144,9 +486,114 @@ func (g *Generator) collectRequiredImports(structs map[string]*types.StructInfo,
 
 		imports = append(imports, info)
 	}
+
+	// Add the imports needed by the generated code, unless the source
+	// package already imports the same path
+	for _, info := range g.imports {
+		if !slices.ContainsFunc(imports, func(imp *types.ImportInfo) bool { return imp.Path == info.Path }) {
+			imports = append(imports, info)
+		}
+	}
 	sort.Slice(imports, func(i, j int) bool {
 		return imports[i].Path < imports[j].Path
 	})
 
 	return imports
 }
+
+// requireImports registers the source package imports with the given aliases,
+// for code referencing types of fields that are not collected by
+// collectRequiredImports.
+func (g *Generator) requireImports(aliases []string) {
+	for _, alias := range aliases {
+		if imp, exists := g.sourceImports[alias]; exists {
+			g.imports[imp.Path] = imp
+		}
+	}
+}
+
+// qualify returns the expression referring to a qualified type, such as
+// "github.com/google/uuid.UUID", and registers the import it needs.
+func (g *Generator) qualify(qualifiedType string) string {
+	importPath, name := types.SplitQualifiedType(qualifiedType)
+	if importPath == "" {
+		return name
+	}
+
+	return g.importPackage(importPath) + "." + name
+}
+
+// importPackage registers the import of a package and returns the name it is
+// referred to by. The alias used by the source package is kept when it
+// imports the same path, else the package name is used unless another import
+// takes it, such as a local package named maps next to the standard one.
+func (g *Generator) importPackage(importPath string) string {
+	if imp, exists := g.imports[importPath]; exists {
+		return imp.Alias
+	}
+	for _, imp := range g.sourceImports {
+		if imp.Path == importPath {
+			g.imports[importPath] = imp
+			return imp.Alias
+		}
+	}
+
+	alias := g.importAlias(importPath)
+	g.imports[importPath] = &types.ImportInfo{
+		Alias:     alias,
+		Path:      importPath,
+		IsAliased: alias != path.Base(importPath),
+	}
+	return alias
+}
+
+// importAlias returns the name of a package imported by the generated code
+// that no other import takes: its own name, else prefixed with "std" for the
+// standard library, else followed by a number.
+func (g *Generator) importAlias(importPath string) string {
+	name := path.Base(importPath)
+	candidates := []string{name}
+	if first, _, _ := strings.Cut(importPath, "/"); !strings.Contains(first, ".") {
+		candidates = append(candidates, "std"+name)
+	}
+	for i := 2; ; i++ {
+		for _, alias := range candidates {
+			if !g.packageNameTaken(alias) {
+				return alias
+			}
+		}
+		candidates = []string{name + strconv.Itoa(i)}
+	}
+}
+
+// packageNameTaken reports whether a package name is already used by an import
+// of the source package or of the generated code, or by the source package
+// itself when generating in another package.
+func (g *Generator) packageNameTaken(name string) bool {
+	if g.sourceImports[name] != nil || name == g.source {
+		return true
+	}
+
+	for _, imp := range g.imports {
+		if imp.Alias == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// zeroValue returns the zero value of a type expression.
+func zeroValue(typeExpr string) string {
+	if typeExpr == "any" {
+		return "nil"
+	}
+
+	for _, prefix := range []string{"*", "[]", "map[", "func(", "chan ", "<-chan "} {
+		if strings.HasPrefix(typeExpr, prefix) {
+			return "nil"
+		}
+	}
+
+	return types.FieldInfo{Type: typeExpr, UnderlyingType: typeExpr}.GetZerovalue()
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"text/template"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// interfaceNamer parses the template naming the read-only interfaces. It
+// returns nil when interfaces are disabled.
+func (g *Generator) interfaceNamer() (*template.Template, error) {
+	if !g.opts.Interfaces {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("interface").Parse(g.opts.InterfaceName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interface name template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// generateInterface generates an interface listing the read-only methods just
+// generated for the struct, followed by an assertion that the struct implements it.
+func (g *Generator) generateInterface(structInfo *types.StructInfo, namer *template.Template) error {
+	var name strings.Builder
+	if err := namer.Execute(&name, structInfo); err != nil {
+		return fmt.Errorf("failed to name interface of struct %s: %w", structInfo.Name, err)
+	}
+
+	interfaceName := name.String()
+	if !token.IsIdentifier(interfaceName) {
+		return fmt.Errorf("invalid interface name %q for struct %s", interfaceName, structInfo.Name)
+	}
+
+	g.Line("// ", interfaceName, " is implemented by *", structInfo.Name, " through its generated getters.")
+	g.Line("type ", interfaceName, " interface {")
+	for _, getter := range g.getters {
+		g.Line(getter)
+	}
+	g.Line("}")
+	g.Line()
+	g.Line("var _ ", interfaceName, " = (*", structInfo.Name, ")(nil)")
+	g.Line()
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// generateFieldIterator generates an All<Field> method ranging over a slice or
+// map field. A nil receiver or a nil pointer to the collection yields nothing.
+// Guarded fields are copied while holding the lock, so that the iteration
+// itself does not race with writers. In deep copy mode, elements holding
+// slices or maps are yielded as copies, like the getter returns them.
+func (g *Generator) generateFieldIterator(structName string, field types.FieldInfo) {
+	if field.Elem == nil || field.IsMap && field.Key == nil {
+		return
+	}
+
+	iterPackage := g.importPackage("iter")
+	iteratorName := "All" + field.MethodName()
+
+	var seqType, yieldType, seqFunc string
+	if field.IsMap {
+		seqType = iterPackage + ".Seq2[" + field.Key.Type + ", " + field.Elem.Type + "]"
+		yieldType = "func(" + field.Key.Type + ", " + field.Elem.Type + ") bool"
+		seqFunc = g.importPackage("maps") + ".All"
+	} else {
+		seqType = iterPackage + ".Seq[" + field.Elem.Type + "]"
+		yieldType = "func(" + field.Elem.Type + ") bool"
+		seqFunc = g.importPackage("slices") + ".Values"
+	}
+
+	value := g.recv + "." + field.Name
+	if field.IsPointer {
+		value = "*" + value
+	}
+
+	g.methodDoc(field.Deprecated, iteratorName+" returns an iterator over the "+field.Name+" field.")
+	g.openGetter(structName, iteratorName, "() ", seqType)
+	depth := g.openNilCheck(field, field.IsPointer, "")
+	lock, _ := g.lockCalls(field, false)
+	switch {
+	case lock != "" && g.copiesElements(field):
+		// The elements are copied while holding the lock as well
+		c := g.local("c")
+		g.writeCopy(c, value, field, true)
+		g.Line("return ", seqFunc, "(", c, ")")
+	case lock != "":
+		g.Line("return ", seqFunc, "(", g.cloneFunc(field), "(", value, "))")
+	case g.copiesElements(field):
+		g.writeElemCopyingSeq(field, value, yieldType)
+	default:
+		g.Line("return ", seqFunc, "(", value, ")")
+	}
+	g.closeNilCheck(depth, "return func(", yieldType, ") {}")
+	g.Line("}")
+	g.Line()
+}
+
+// writeElemCopyingSeq writes the return of an iterator over the collection of
+// field read through expr, yielding copies of its elements.
+func (g *Generator) writeElemCopyingSeq(field types.FieldInfo, expr, yieldType string) {
+	collection, k, v, yield := g.local("s"), g.local("k"), g.local("v"), g.local("yield")
+	key := "_"
+	if field.IsMap {
+		collection, key = g.local("m"), k
+	}
+
+	g.Line(collection, " := ", expr)
+	g.Line("return func(", yield, " ", yieldType, ") {")
+	g.Line("for ", key, ", ", v, " := range ", collection, " {")
+	g.writeElemCopy(v, v, field)
+	if field.IsMap {
+		g.Line("if !", yield, "(", k, ", ", v, ") {")
+	} else {
+		g.Line("if !", yield, "(", v, ") {")
+	}
+	g.Line("return")
+	g.Line("}")
+	g.Line("}")
+	g.Line("}")
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// lockCalls returns the statements acquiring and releasing the struct's lock
+// around an access to field. Both are empty when the field is not guarded,
+// which is always the case for sync/atomic fields.
+func (g *Generator) lockCalls(field types.FieldInfo, write bool) (lock, unlock string) {
+	if g.lock == nil || field.Unguarded || field.AtomicType != "" {
+		return "", ""
+	}
+
+	mutex := g.recv + "." + g.lock.Name
+	if !write && g.lock.Lock == types.LockRWMutex {
+		return mutex + ".RLock()", mutex + ".RUnlock()"
+	}
+
+	return mutex + ".Lock()", mutex + ".Unlock()"
+}
+
+// openNilCheck opens the block run when the receiver is not nil, the field is
+// not nil if checkField is set, and the extra condition holds if given.
+// Value receivers are never nil, so no block is opened for them when there is
+// no other condition. When the field is guarded, the read lock is held for the
+// rest of the block. It returns the number of blocks to close with closeBlocks
+// or closeNilCheck.
+func (g *Generator) openNilCheck(field types.FieldInfo, checkField bool, extra string) int {
+	var conditions []string
+	if checkField {
+		conditions = append(conditions, g.recv+"."+field.Name+" != nil")
+	}
+	if extra != "" {
+		conditions = append(conditions, extra)
+	}
+
+	if g.value {
+		if len(conditions) == 0 {
+			return 0
+		}
+
+		g.Line("if ", joinConditions(conditions), " {")
+		return 1
+	}
+
+	lock, unlock := g.lockCalls(field, false)
+	if lock == "" {
+		g.Line("if ", joinConditions(append([]string{g.recv + " != nil"}, conditions...)), " {")
+		return 1
+	}
+
+	g.Line("if ", g.recv, " != nil {")
+	g.Line(lock)
+	g.Line("defer ", unlock)
+	if len(conditions) == 0 {
+		return 1
+	}
+
+	g.Line("if ", joinConditions(conditions), " {")
+	return 2
+}
+
+// closeBlocks closes the given number of blocks.
+func (g *Generator) closeBlocks(n int) {
+	for range n {
+		g.Line("}")
+	}
+}
+
+// closeNilCheck closes the blocks opened by openNilCheck, followed by the
+// statement run when a check fails, which is left out when nothing was checked
+// since it would be unreachable.
+func (g *Generator) closeNilCheck(depth int, fallback ...any) {
+	g.closeBlocks(depth)
+	if depth > 0 {
+		g.Line(fallback...)
+	}
+}
+
+// joinConditions joins boolean expressions with &&.
+func joinConditions(conditions []string) string {
+	return strings.Join(conditions, " && ")
+}
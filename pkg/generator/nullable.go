@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// nullableType returns the registered nullable wrapper matching the field's type, if any.
+func (g *Generator) nullableType(field types.FieldInfo) *types.NullableType {
+	if field.IsCollection() {
+		return nil
+	}
+
+	typeName, _, _ := strings.Cut(field.UnderlyingType, "[")
+	if alias, name, ok := strings.Cut(typeName, "."); ok && alias == g.source {
+		// Types of the source package are registered unqualified
+		typeName = name
+	} else if ok {
+		imp, exists := g.sourceImports[alias]
+		if !exists {
+			return nil
+		}
+		typeName = imp.Path + "." + name
+	}
+
+	for i := range g.opts.NullableTypes {
+		if g.opts.NullableTypes[i].Type == typeName {
+			return &g.opts.NullableTypes[i]
+		}
+	}
+
+	return nil
+}
+
+// nullableResult returns the value held by a nullable wrapper read through
+// expr, or its zero value when unset.
+func (g *Generator) nullableResult(field types.FieldInfo, expr string, nilCheck []string, nullable *types.NullableType) getterResult {
+	valueType := g.qualify(nullable.ValueType)
+	if nullable.ValueType == "" {
+		// Generic wrappers hold a value of their type argument
+		_, typeArgs, _ := strings.Cut(field.UnderlyingType, "[")
+		valueType = strings.TrimSuffix(typeArgs, "]")
+	}
+
+	return getterResult{
+		Type:       valueType,
+		Conditions: append(nilCheck, expr+"."+nullable.ValidField),
+		Zero:       zeroValue(valueType),
+		Return:     func() { g.Line("return ", expr, ".", nullable.ValueField) },
+	}
+}
+
+// generateNullableHas generates a Has<Field> method reporting whether a
+// nullable wrapper is set.
+func (g *Generator) generateNullableHas(structName string, field types.FieldInfo, nullable *types.NullableType) {
+	g.methodDoc(field.Deprecated, "Has"+field.MethodName()+" reports whether the "+field.Name+" field is set.")
+	g.openGetter(structName, "Has", field.MethodName(), "() bool")
+	depth := g.openNilCheck(field, field.IsPointer, "")
+	g.Line("return ", g.recv, ".", field.Name, ".", nullable.ValidField)
+	g.closeNilCheck(depth, "return false")
+	g.Line("}")
+	g.Line()
+}
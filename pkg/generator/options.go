@@ -0,0 +1,281 @@
+package generator
+
+import "github.com/renxzen/go-getters/pkg/types"
+
+// Options configures the code produced by the Generator.
+type Options struct {
+	// CopyMode controls whether getters for slice and map fields return a copy
+	// of the collection. Fields can override it with their getter tag.
+	CopyMode types.CopyMode
+
+	// Iterators adds All<Field> methods returning an iter.Seq for slice fields
+	// and an iter.Seq2 for map fields.
+	Iterators bool
+
+	// Accessors adds Lookup<Field> methods for map fields, <Field>At methods for
+	// slice fields and <Field>Len methods for both.
+	Accessors bool
+
+	// Setters adds Set<Field> methods for exported fields.
+	Setters bool
+
+	// NullableTypes lists the wrapper types unwrapped by getters, such as
+	// sql.NullString. It defaults to types.DefaultNullableTypes.
+	NullableTypes []types.NullableType
+
+	// Interfaces adds, for each struct, an interface listing its read-only
+	// methods along with a compile-time assertion that the struct implements it.
+	Interfaces bool
+
+	// InterfaceName is the text/template naming the interfaces, executed with
+	// the types.StructInfo of the struct. It defaults to "{{.Name}}Reader".
+	InterfaceName string
+
+	// FieldMetadata adds, for each struct, <Struct>Field<Name> constants holding
+	// the field names and a <Struct>Fields table describing each field.
+	FieldMetadata bool
+
+	// FieldAccess adds GetField and FieldNames methods reading fields by name
+	// through their getters, and SetField when setters are enabled.
+	FieldAccess bool
+
+	// PathDepth enables flattened getters reaching into fields holding other
+	// structs of the package, such as GetAddressCity for Address.City, down to
+	// the given number of nested structs. Zero disables them.
+	PathDepth int
+
+	// TemplateFiles replaces the built-in output with user text/templates,
+	// executed with TemplateData. The first file is executed, and the built-in
+	// template remains available as "default".
+	TemplateFiles []string
+
+	// DocComments adds doc comments to the generated methods. Getters are
+	// documented from the comments of their fields.
+	DocComments bool
+
+	// DeprecationHook is a function called with a warning message the first
+	// time the getter or setter of a deprecated field is called, given with
+	// its import path, e.g. "log.Print". Empty disables the warnings.
+	DeprecationHook string
+
+	// ValueReceivers lists the structs whose getters use a value receiver,
+	// without nil checks, in addition to the structs marked with the
+	// //getters:value directive. Setters keep pointer receivers.
+	ValueReceivers []string
+
+	// Receiver names the receiver of the generated methods, unless a struct
+	// names it with the //getters:receiver directive. When empty, structs use
+	// the name most used by their existing methods, else "x".
+	Receiver string
+
+	// GetterPrefix and SetterPrefix start the names of getters and setters.
+	// They default to "Get" and "Set".
+	GetterPrefix string
+	SetterPrefix string
+
+	// Deref controls whether getters of pointer fields return the value they
+	// point to. It defaults to types.DerefAuto.
+	Deref types.DerefPolicy
+
+	// OutputPackage is the name of the package the code is generated in, when
+	// it differs from the source package. Since methods can't be declared on
+	// the types of another package, getters and the other methods become
+	// functions taking the struct as their first parameter, such as
+	// UserName(x *models.User) for User.GetName. Empty generates methods.
+	OutputPackage string
+
+	// SourcePath is the import path of the source package, imported by the
+	// code generated in OutputPackage.
+	SourcePath string
+
+	// Overrides holds settings taking precedence over the getter tags and
+	// directives of the source, such as those given on the command line.
+	Overrides Overrides
+
+	// Structs holds the options applied on top of the others for the
+	// generated declarations of a struct, by struct name.
+	Structs map[string][]Option
+}
+
+// Overrides holds settings taking precedence over getter tags and directives.
+// Empty values are unset.
+type Overrides struct {
+	// CopyMode replaces the copy mode of every field, including those set by
+	// their getter tag.
+	CopyMode types.CopyMode
+
+	// Receiver names the receiver of the generated methods of every struct,
+	// including those naming it with the //getters:receiver directive.
+	Receiver string
+}
+
+// Option modifies the Options of a Generator.
+type Option func(*Options)
+
+// DefaultOptions returns the options used when none are given.
+func DefaultOptions() Options {
+	return Options{
+		CopyMode:      types.CopyNone,
+		NullableTypes: types.DefaultNullableTypes(),
+		InterfaceName: "{{.Name}}Reader",
+		GetterPrefix:  "Get",
+		SetterPrefix:  "Set",
+		Deref:         types.DerefAuto,
+	}
+}
+
+// WithCopyMode sets the default copy mode for slice and map getters.
+func WithCopyMode(mode types.CopyMode) Option {
+	return func(o *Options) {
+		o.CopyMode = mode
+	}
+}
+
+// WithIterators enables iterator methods for slice and map fields.
+func WithIterators(enabled bool) Option {
+	return func(o *Options) {
+		o.Iterators = enabled
+	}
+}
+
+// WithAccessors enables element accessor methods for slice and map fields.
+func WithAccessors(enabled bool) Option {
+	return func(o *Options) {
+		o.Accessors = enabled
+	}
+}
+
+// WithSetters enables setter methods for exported fields.
+func WithSetters(enabled bool) Option {
+	return func(o *Options) {
+		o.Setters = enabled
+	}
+}
+
+// WithNullableTypes registers additional nullable wrapper types.
+func WithNullableTypes(nullableTypes ...types.NullableType) Option {
+	return func(o *Options) {
+		o.NullableTypes = append(o.NullableTypes, nullableTypes...)
+	}
+}
+
+// WithInterfaces enables read-only interfaces, named by the given text/template.
+// An empty name keeps the default one.
+func WithInterfaces(enabled bool, name string) Option {
+	return func(o *Options) {
+		o.Interfaces = enabled
+		if name != "" {
+			o.InterfaceName = name
+		}
+	}
+}
+
+// WithInterfaceName sets the text/template naming the read-only interfaces.
+func WithInterfaceName(name string) Option {
+	return func(o *Options) {
+		o.InterfaceName = name
+	}
+}
+
+// WithFieldMetadata enables field name constants and field metadata tables.
+func WithFieldMetadata(enabled bool) Option {
+	return func(o *Options) {
+		o.FieldMetadata = enabled
+	}
+}
+
+// WithFieldAccess enables reflection-free access to fields by name.
+func WithFieldAccess(enabled bool) Option {
+	return func(o *Options) {
+		o.FieldAccess = enabled
+	}
+}
+
+// WithPathGetters enables flattened getters for nested structs down to the given depth.
+func WithPathGetters(depth int) Option {
+	return func(o *Options) {
+		o.PathDepth = depth
+	}
+}
+
+// WithTemplateFiles renders the generated declarations with the given text/template files.
+func WithTemplateFiles(paths ...string) Option {
+	return func(o *Options) {
+		o.TemplateFiles = append(o.TemplateFiles, paths...)
+	}
+}
+
+// WithDocComments enables doc comments on generated methods.
+func WithDocComments(enabled bool) Option {
+	return func(o *Options) {
+		o.DocComments = enabled
+	}
+}
+
+// WithDeprecationHook sets the function warning about uses of deprecated fields.
+func WithDeprecationHook(hook string) Option {
+	return func(o *Options) {
+		o.DeprecationHook = hook
+	}
+}
+
+// WithValueReceivers makes the getters of the given structs use value receivers.
+func WithValueReceivers(structNames ...string) Option {
+	return func(o *Options) {
+		o.ValueReceivers = append(o.ValueReceivers, structNames...)
+	}
+}
+
+// WithReceiver names the receiver of the generated methods.
+func WithReceiver(name string) Option {
+	return func(o *Options) {
+		o.Receiver = name
+	}
+}
+
+// WithMethodPrefixes sets the prefixes of getter and setter names. Empty
+// prefixes keep the current ones.
+func WithMethodPrefixes(getter, setter string) Option {
+	return func(o *Options) {
+		if getter != "" {
+			o.GetterPrefix = getter
+		}
+		if setter != "" {
+			o.SetterPrefix = setter
+		}
+	}
+}
+
+// WithDeref sets whether getters of pointer fields dereference them.
+func WithDeref(policy types.DerefPolicy) Option {
+	return func(o *Options) {
+		o.Deref = policy
+	}
+}
+
+// WithOutputPackage generates functions in the named package, importing the
+// source package from sourcePath, instead of methods in the source package.
+func WithOutputPackage(name, sourcePath string) Option {
+	return func(o *Options) {
+		o.OutputPackage = name
+		o.SourcePath = sourcePath
+	}
+}
+
+// WithOverrides sets the settings taking precedence over getter tags and directives.
+func WithOverrides(overrides Overrides) Option {
+	return func(o *Options) {
+		o.Overrides = overrides
+	}
+}
+
+// WithStructOptions applies options to the generated declarations of a single
+// struct, on top of the options given for all of them.
+func WithStructOptions(structName string, opts ...Option) Option {
+	return func(o *Options) {
+		if o.Structs == nil {
+			o.Structs = make(map[string][]Option)
+		}
+		o.Structs[structName] = append(o.Structs[structName], opts...)
+	}
+}
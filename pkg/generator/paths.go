@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// generatePathGetters generates flattened getters for the fields of nested
+// structs, so that Container.ExamplePtr.Name is read with GetExamplePtrName
+// whether or not Example has getters of its own. Every pointer along the path
+// is checked for nil. Only structs of the parsed package can be followed, and
+// structs guarded by their own lock are not, since their fields can't be read
+// safely without it.
+func (g *Generator) generatePathGetters(structInfo *types.StructInfo) {
+	// Names of the struct's own getters, which take precedence over paths
+	taken := make(map[string]bool)
+	for _, field := range structInfo.Fields {
+		taken[g.getterName(field)] = true
+	}
+
+	for _, field := range structInfo.Fields {
+		if nested := g.nestedStruct(field); nested != nil {
+			g.generateNestedGetters(structInfo.Name, []types.FieldInfo{field}, nested, taken)
+		}
+	}
+}
+
+// generateNestedGetters generates the path getters for the fields of nested,
+// reached through path, and recurses into the structs nested in it.
+func (g *Generator) generateNestedGetters(structName string, path []types.FieldInfo, nested *types.StructInfo, taken map[string]bool) {
+	for _, field := range nested.Fields {
+		if !hasGetter(field) {
+			continue
+		}
+
+		fieldPath := slices.Concat(path, []types.FieldInfo{field})
+		g.generatePathGetter(structName, fieldPath, taken)
+
+		if next := g.nestedStruct(field); next != nil && len(fieldPath) <= g.opts.PathDepth {
+			g.generateNestedGetters(structName, fieldPath, next, taken)
+		}
+	}
+}
+
+// nestedStruct returns the struct of the package held by the field, either by
+// value or through a pointer, or nil if there is none to follow.
+func (g *Generator) nestedStruct(field types.FieldInfo) *types.StructInfo {
+	if !hasGetter(field) || field.IsCollection() {
+		return nil
+	}
+
+	nested, exists := g.structs[field.UnderlyingType]
+	if !exists || nested.LockField() != nil {
+		return nil
+	}
+
+	return nested
+}
+
+// generatePathGetter generates a getter for the last field of path, checking
+// every pointer leading to it, and returning the same value as the getter of
+// the last field. Deprecated fields along the path are reported to the
+// deprecation hook like by their own getters.
+func (g *Generator) generatePathGetter(structName string, path []types.FieldInfo, taken map[string]bool) {
+	name := g.opts.GetterPrefix
+	for _, field := range path {
+		name += field.MethodName()
+	}
+	if taken[name] {
+		return
+	}
+	taken[name] = true
+
+	root, leaf := path[0], path[len(path)-1]
+	g.requireImports(leaf.RequiredImports)
+
+	// Nil checks of the pointers between the root, which openNilCheck
+	// handles, and the leaf, whose own checks come with its value
+	var conditions []string
+	expr := g.recv + "." + root.Name
+	for _, field := range path[1 : len(path)-1] {
+		expr += "." + field.Name
+		if field.IsPointer {
+			conditions = append(conditions, expr+" != nil")
+		}
+	}
+	expr += "." + leaf.Name
+	result := g.getterResult(leaf, expr)
+
+	// Structs declaring the fields of the path
+	owners := []string{structName}
+	for _, field := range path[:len(path)-1] {
+		owners = append(owners, g.nestedStruct(field).Name)
+	}
+
+	var deprecated string
+	for i, field := range path {
+		if field.Deprecated != "" && deprecated == "" {
+			deprecated = field.Deprecated
+		}
+		g.declareDeprecationOnce(owners[i], field)
+	}
+
+	g.methodDoc(deprecated, name+" returns the "+strings.TrimPrefix(expr, g.recv+".")+" field, or its zero value",
+		"if a pointer leading to it is nil.")
+	g.openGetter(structName, name, "() ", result.Type)
+	for i, field := range path {
+		g.warnDeprecated(owners[i], field)
+	}
+	depth := g.openNilCheck(root, root.IsPointer, joinConditions(append(conditions, result.Conditions...)))
+	result.Return()
+	g.closeNilCheck(depth, "return ", result.Zero)
+	g.Line("}")
+	g.Line()
+}
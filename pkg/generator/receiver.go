@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"go/token"
+	gotypes "go/types"
+	"slices"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// defaultReceiver names the receiver of generated methods when no name is
+// configured or inferred.
+const defaultReceiver = "x"
+
+// generatedPackages lists the packages the generated methods can refer to,
+// besides those imported by the source package.
+var generatedPackages = []string{"fmt", "iter", "maps", "slices", "sync"}
+
+// receiverName returns the name of the receiver of the struct's generated
+// methods: the overriding one, else the one given by the struct's directive,
+// else by the options, else the one most used by its existing methods, so
+// that they stay consistent.
+// Names that would collide with a package used by the generated methods are
+// replaced by the default one, while their parameters and variables are
+// renamed after the receiver, see local.
+func (g *Generator) receiverName(structInfo *types.StructInfo) string {
+	name, source := g.opts.Overrides.Receiver, "the options"
+	if name == "" {
+		name, source = structInfo.Receiver, "its directive"
+	}
+	if name == "" {
+		name, source = g.opts.Receiver, "the options"
+	}
+	if name == "" {
+		name, source = inferReceiver(structInfo.Methods), "its methods"
+	}
+	if name == "" {
+		return defaultReceiver
+	}
+
+	if reason := g.receiverConflict(name); reason != "" {
+		g.diags.Warnf(structInfo.Position, diag.ReceiverName, "%s: using receiver %s instead of %s from %s, which %s", structInfo.Name, defaultReceiver, name, source, reason)
+		return defaultReceiver
+	}
+
+	return name
+}
+
+// receiverConflict returns why the generated methods can't use name for
+// their receiver, or an empty string if they can.
+func (g *Generator) receiverConflict(name string) string {
+	switch {
+	case !token.IsIdentifier(name) || name == "_":
+		return "is not a valid receiver name"
+	case gotypes.Universe.Lookup(name) != nil:
+		return "shadows a predeclared identifier"
+	case slices.Contains(generatedPackages, name) || g.sourceImports[name] != nil || g.hookPackage() == name || name == g.source:
+		return "collides with an imported package"
+	default:
+		return ""
+	}
+}
+
+// local returns the name of a parameter or variable of the generated methods,
+// followed by an underscore when the receiver already uses it, so that a
+// receiver named v keeps its name next to the v parameter of setters.
+func (g *Generator) local(name string) string {
+	if name == g.recv {
+		return name + "_"
+	}
+
+	return name
+}
+
+// inferReceiver returns the receiver name most used by the methods, the first
+// in alphabetical order on ties, or an empty string if none is named.
+func inferReceiver(methods []types.MethodInfo) string {
+	counts := make(map[string]int)
+	for _, method := range methods {
+		if method.Receiver != "" && method.Receiver != "_" {
+			counts[method.Receiver]++
+		}
+	}
+
+	var name string
+	for receiver, count := range counts {
+		if count > counts[name] || count == counts[name] && receiver < name {
+			name = receiver
+		}
+	}
+
+	return name
+}
+
+// valueReceiver reports whether the getters of the struct use a value
+// receiver, as requested by the options or the struct's directive. Structs
+// holding values that must not be copied keep pointer receivers. Since setters
+// and hand-written pointer methods can't be called on values, mixing both
+// kinds of receivers is reported as a warning.
+func (g *Generator) valueReceiver(structInfo *types.StructInfo) bool {
+	if !structInfo.ValueReceiver && !slices.Contains(g.opts.ValueReceivers, structInfo.Name) {
+		return false
+	}
+
+	for _, field := range structInfo.Fields {
+		if field.NoCopy || field.Lock != types.LockNone {
+			g.diags.Warnf(field.Position, diag.ValueReceiver, "%s: keeping pointer receivers, since value receivers would copy its field %s", structInfo.Name, field.Name)
+			return false
+		}
+	}
+
+	if g.opts.Setters {
+		g.diags.Warnf(structInfo.Position, diag.ValueReceiver, "%s: setters keep pointer receivers, mixed with value receiver getters", structInfo.Name)
+	}
+
+	var pointerMethods []string
+	for _, method := range structInfo.Methods {
+		if method.IsPointer {
+			pointerMethods = append(pointerMethods, method.Name)
+		}
+	}
+	if len(pointerMethods) > 0 {
+		g.diags.Warnf(structInfo.Position, diag.ValueReceiver, "%s: value receiver getters are mixed with the pointer receiver methods %s", structInfo.Name, strings.Join(pointerMethods, ", "))
+	}
+
+	return true
+}
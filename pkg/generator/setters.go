@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// generateFieldSetter generates a setter method for a single field. Unlike
+// getters, setters are not nil-safe since there is nothing to write to.
+// Guarded fields are written while holding the struct's write lock, and
+// sync/atomic fields with their Store method.
+func (g *Generator) generateFieldSetter(structName string, field types.FieldInfo) {
+	setterName := g.setterName(field)
+	v := g.local("v")
+
+	if field.AtomicType != "" {
+		g.methodDoc(field.Deprecated, setterName+" stores "+v+" in the "+field.Name+" field.")
+		g.openMethod(structName, setterName, "(", v, " ", setterType(field), ")")
+		g.warnDeprecated(structName, field)
+		g.Line(g.recv, ".", field.Name, ".Store(", v, ")")
+		g.Line("}")
+		g.Line()
+		return
+	}
+
+	g.methodDoc(field.Deprecated, setterName+" sets the "+field.Name+" field.")
+	g.openMethod(structName, setterName, "(", v, " ", setterType(field), ")")
+	g.warnDeprecated(structName, field)
+	if lock, unlock := g.lockCalls(field, true); lock != "" {
+		g.Line(lock)
+		g.Line("defer ", unlock)
+	}
+	g.Line(g.recv, ".", field.Name, " = ", v)
+	g.Line("}")
+	g.Line()
+}
+
+// setterName returns the name of the setter generated for a field.
+func (g *Generator) setterName(field types.FieldInfo) string {
+	return g.opts.SetterPrefix + field.MethodName()
+}
+
+// setterType returns the type of the value taken by the setter of a field.
+func setterType(field types.FieldInfo) string {
+	if field.AtomicType != "" {
+		return field.AtomicType
+	}
+
+	return field.Type
+}
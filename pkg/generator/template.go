@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"path/filepath"
+	"text/template"
+
+	"github.com/renxzen/go-getters/pkg/strutils"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+//go:embed templates/default.tmpl
+var defaultTemplate string
+
+// TemplateData is the data templates are executed with.
+type TemplateData struct {
+	PackageName string
+	Structs     []*types.StructInfo // Requested structs, in the requested order
+}
+
+// template returns the template rendering the generated declarations. The
+// built-in template is named "default" and remains available to user
+// templates, the first of which is executed when any is given.
+func (g *Generator) template() (*template.Template, error) {
+	tmpl, err := template.New("default").Funcs(g.templateFuncs()).Parse(defaultTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default template: %w", err)
+	}
+
+	if len(g.opts.TemplateFiles) == 0 {
+		return tmpl, nil
+	}
+
+	tmpl, err = tmpl.ParseFiles(g.opts.TemplateFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	return tmpl.Lookup(filepath.Base(g.opts.TemplateFiles[0])), nil
+}
+
+// templateFuncs returns the functions available to templates.
+func (g *Generator) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// getters renders the built-in methods of a struct
+		"getters": func(structInfo *types.StructInfo) (string, error) {
+			buf := g.buf
+			defer func() { g.buf = buf }()
+
+			g.buf = &bytes.Buffer{}
+			if err := g.generateStruct(structInfo); err != nil {
+				return "", err
+			}
+			return g.buf.String(), nil
+		},
+		"hasGetter":    hasGetter,
+		"getterName":   g.getterName,
+		"setterName":   g.setterName,
+		"methodName":   types.FieldInfo.MethodName,
+		"dereferences": g.dereferences,
+		"receiver":     g.receiverName,
+		// zero returns the zero value of a field's getter or of a type expression
+		"zero": func(v any) (string, error) {
+			switch v := v.(type) {
+			case types.FieldInfo:
+				return g.getterZero(v), nil
+			case string:
+				return zeroValue(v), nil
+			default:
+				return "", fmt.Errorf("zero: unsupported argument of type %T", v)
+			}
+		},
+		// qualify refers to a type given with its import path, such as
+		// "github.com/google/uuid.UUID", and imports its package
+		"qualify": g.qualify,
+		// import imports a package and returns the name it is referred to by
+		"import":      g.importPackage,
+		"capitalize":  strutils.Capitalize,
+		"singularize": strutils.Singularize,
+	}
+}
@@ -0,0 +1,203 @@
+// Package inspect exports the model built by the parser as JSON, so that other
+// tools can reuse the parsing of go-getters. The JSON schema is versioned
+// separately from the types of the parser.
+package inspect
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// SchemaVersion is the version of the JSON schema of Model. It is increased
+// whenever a field is renamed or removed, or changes meaning; new fields may
+// be added without increasing it.
+const SchemaVersion = 1
+
+// Model is the JSON representation of a parsed package.
+type Model struct {
+	SchemaVersion int      `json:"schemaVersion"`
+	Package       string   `json:"package"`
+	Imports       []Import `json:"imports"` // Sorted by alias
+	Structs       []Struct `json:"structs"` // Sorted by name
+	Types         []Type   `json:"types"`   // Named types that are not structs, sorted by name
+}
+
+// Position is the position of a declaration in the source.
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Import is an import of the package.
+type Import struct {
+	Alias     string `json:"alias"`
+	Path      string `json:"path"`
+	IsAliased bool   `json:"isAliased"`
+}
+
+// Type is a named type that is not a struct.
+type Type struct {
+	Name     string    `json:"name"`
+	Position *Position `json:"position,omitempty"`
+	Kind     string    `json:"kind"`
+	Target   string    `json:"target,omitempty"`
+}
+
+// Struct is a struct of the package.
+type Struct struct {
+	Name          string    `json:"name"`
+	Position      *Position `json:"position,omitempty"`
+	Fields        []Field   `json:"fields"`
+	Methods       []Method  `json:"methods"`
+	ValueReceiver bool      `json:"valueReceiver"`
+	Receiver      string    `json:"receiver,omitempty"`
+}
+
+// Method is a hand-written method of a struct.
+type Method struct {
+	Name      string    `json:"name"`
+	Position  *Position `json:"position,omitempty"`
+	Receiver  string    `json:"receiver,omitempty"`
+	IsPointer bool      `json:"isPointer"`
+}
+
+// Field is a struct field, or the key or element type of a collection field.
+type Field struct {
+	Name            string    `json:"name,omitempty"`
+	Position        *Position `json:"position,omitempty"`
+	Type            string    `json:"type"`
+	UnderlyingType  string    `json:"underlyingType"`
+	Unsupported     string    `json:"unsupported,omitempty"`
+	Tag             string    `json:"tag,omitempty"`
+	Doc             string    `json:"doc,omitempty"`
+	Deprecated      string    `json:"deprecated,omitempty"`
+	Rename          string    `json:"rename,omitempty"`
+	Skip            bool      `json:"skip"`
+	IsPointer       bool      `json:"isPointer"`
+	IsExported      bool      `json:"isExported"`
+	IsSlice         bool      `json:"isSlice"`
+	IsMap           bool      `json:"isMap"`
+	IsEmbedded      bool      `json:"isEmbedded"`
+	RequiredImports []string  `json:"requiredImports,omitempty"`
+	Key             *Field    `json:"key,omitempty"`
+	Elem            *Field    `json:"elem,omitempty"`
+	CopyMode        string    `json:"copyMode,omitempty"`
+	Lock            string    `json:"lock,omitempty"`
+	Unguarded       bool      `json:"unguarded"`
+	AtomicType      string    `json:"atomicType,omitempty"`
+	NoCopy          bool      `json:"noCopy"`
+}
+
+// New converts a parse result into its JSON model.
+func New(result *types.ParseResult) *Model {
+	model := &Model{
+		SchemaVersion: SchemaVersion,
+		Package:       result.PackageName,
+		Imports:       []Import{},
+		Structs:       []Struct{},
+		Types:         []Type{},
+	}
+
+	for _, alias := range slices.Sorted(maps.Keys(result.Imports)) {
+		imp := result.Imports[alias]
+		model.Imports = append(model.Imports, Import{Alias: imp.Alias, Path: imp.Path, IsAliased: imp.IsAliased})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(result.Structs)) {
+		model.Structs = append(model.Structs, newStruct(result.Structs[name]))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(result.Types)) {
+		typeInfo := result.Types[name]
+		model.Types = append(model.Types, Type{
+			Name:     typeInfo.Name,
+			Position: newPosition(typeInfo.Position),
+			Kind:     typeInfo.Kind,
+			Target:   typeInfo.Target,
+		})
+	}
+
+	return model
+}
+
+// Write writes the JSON model of a parse result, indented.
+func Write(w io.Writer, result *types.ParseResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(New(result))
+}
+
+// newStruct converts a struct into its JSON model.
+func newStruct(structInfo *types.StructInfo) Struct {
+	s := Struct{
+		Name:          structInfo.Name,
+		Position:      newPosition(structInfo.Position),
+		Fields:        make([]Field, 0, len(structInfo.Fields)),
+		Methods:       make([]Method, 0, len(structInfo.Methods)),
+		ValueReceiver: structInfo.ValueReceiver,
+		Receiver:      structInfo.Receiver,
+	}
+
+	for _, field := range structInfo.Fields {
+		s.Fields = append(s.Fields, *newField(&field))
+	}
+
+	for _, method := range structInfo.Methods {
+		s.Methods = append(s.Methods, Method{
+			Name:      method.Name,
+			Position:  newPosition(method.Position),
+			Receiver:  method.Receiver,
+			IsPointer: method.IsPointer,
+		})
+	}
+
+	return s
+}
+
+// newField converts a field into its JSON model, returning nil for a nil field.
+func newField(field *types.FieldInfo) *Field {
+	if field == nil {
+		return nil
+	}
+
+	return &Field{
+		Name:            field.Name,
+		Position:        newPosition(field.Position),
+		Type:            field.Type,
+		UnderlyingType:  field.UnderlyingType,
+		Unsupported:     field.Unsupported,
+		Tag:             field.Tag,
+		Doc:             field.Doc,
+		Deprecated:      field.Deprecated,
+		Rename:          field.Rename,
+		Skip:            field.Skip,
+		IsPointer:       field.IsPointer,
+		IsExported:      field.IsExported,
+		IsSlice:         field.IsSlice,
+		IsMap:           field.IsMap,
+		IsEmbedded:      field.IsEmbedded,
+		RequiredImports: field.RequiredImports,
+		Key:             newField(field.Key),
+		Elem:            newField(field.Elem),
+		CopyMode:        string(field.CopyMode),
+		Lock:            string(field.Lock),
+		Unguarded:       field.Unguarded,
+		AtomicType:      field.AtomicType,
+		NoCopy:          field.NoCopy,
+	}
+}
+
+// newPosition converts a position, returning nil if it is invalid.
+func newPosition(pos token.Position) *Position {
+	if !pos.IsValid() {
+		return nil
+	}
+
+	return &Position{File: pos.Filename, Line: pos.Line, Column: pos.Column}
+}
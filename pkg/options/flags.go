@@ -0,0 +1,163 @@
+package options
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/config"
+	"github.com/renxzen/go-getters/pkg/selector"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// RegisterFlags defines the command line flags setting the options in fs.
+// Generation settings are only set by the flags given, so that the others
+// keep the values of the configuration file.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	o.RegisterInputFlags(fs)
+
+	fs.Func("structs", "Comma-separated list of struct names or glob patterns (e.g. *Request) to generate getters for", func(s string) error {
+		o.Structs = splitList(s)
+		return nil
+	})
+	fs.BoolVar(&o.All, "all", false, "Generate getters for every struct of the package")
+	fs.Func("exclude", "Regular expression matching structs never selected by patterns and -all (repeatable)", func(s string) error {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return err
+		}
+		o.Exclude = append(o.Exclude, re)
+		return nil
+	})
+	fs.Func("visibility", "Keep only exported or unexported structs matched by patterns and -all: all, exported or unexported (default \"all\")", func(s string) (err error) {
+		o.Visibility, err = selector.ParseVisibility(s)
+		return err
+	})
+	fs.BoolVar(&o.Lenient, "lenient", false, "Report patterns and names matching no struct as warnings instead of errors")
+
+	fs.Func("nullable", "Register a nullable wrapper type as Type:ValueField[:ValueType[:ValidField]] (repeatable)", func(s string) error {
+		nullable, err := types.ParseNullableType(s)
+		if err != nil {
+			return err
+		}
+		o.NullableTypes = append(o.NullableTypes, nullable)
+		return nil
+	})
+	fs.Func("template", "Render the output with a text/template file instead of the built-in one (repeatable)", func(s string) error {
+		o.TemplateFiles = append(o.TemplateFiles, s)
+		return nil
+	})
+	fs.Func("value-receivers", "Comma-separated list of structs whose getters use value receivers", func(s string) error {
+		o.ValueReceivers = splitList(s)
+		return nil
+	})
+
+	s := &o.Settings
+	fs.Var(stringSetting(&s.Copy, "none", parseCopyMode), "copy", "Copy mode for slice and map getters: none, shallow or deep")
+	fs.Var(boolSetting(&s.Iterators), "iter", "Generate All<Field> iterator methods for slice and map fields")
+	fs.Var(boolSetting(&s.Accessors), "accessors", "Generate Lookup<Field>, <Field>At and <Field>Len accessors for slice and map fields")
+	fs.Var(boolSetting(&s.Setters), "setters", "Generate Set<Field> methods for exported fields")
+	fs.Var(boolSetting(&s.Interfaces), "interfaces", "Generate a read-only interface listing the getters of each struct")
+	fs.Var(stringSetting(&s.InterfaceName, "{{.Name}}Reader", nil), "interface-name", "Template naming the generated interfaces")
+	fs.Var(boolSetting(&s.Fields), "fields", "Generate field name constants and a field metadata table for each struct")
+	fs.Var(boolSetting(&s.FieldAccess), "field-access", "Generate GetField and FieldNames methods, and SetField with -setters")
+	fs.Var(&setting[int]{dst: &s.Paths, parse: strconv.Atoi}, "paths", "Generate flattened getters for nested structs down to this depth (0 disables them)")
+	fs.Var(boolSetting(&s.Docs), "docs", "Add doc comments to the generated methods, derived from the field comments")
+	fs.Var(stringSetting(&s.DeprecationHook, "", nil), "deprecation-hook", "Function warning once about uses of deprecated fields, with its import path (e.g. log.Print)")
+	fs.Var(stringSetting(&s.Receiver, "", parseIdentifier), "receiver", "Receiver name of the generated methods (default: inferred from existing methods, else x)")
+	fs.Var(stringSetting(&s.Deref, "auto", parseDerefPolicy), "deref", "Dereference policy for pointer fields: auto (pointers to primitives and slices) or never")
+	fs.Var(stringSetting(&s.GetterPrefix, "Get", parseIdentifier), "getter-prefix", "Prefix of getter names")
+	fs.Var(stringSetting(&s.SetterPrefix, "Set", parseIdentifier), "setter-prefix", "Prefix of setter names")
+}
+
+// RegisterInputFlags defines the flags locating the package, its output file
+// and its configuration file in fs.
+func (o *Options) RegisterInputFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Input, "input", ".", "Path to directory containing Go files")
+	fs.StringVar(&o.Output, "output", "", "Output file, relative to the input directory; in another directory, functions of its package are generated (default: named after the file and the struct of a bare go:generate directive, else from the configuration file, else "+DefaultOutput+")")
+	fs.StringVar(&o.Config, "config", "", "Configuration file (default: "+config.FileName+" in the input directory or its parents)")
+}
+
+// setting is a flag.Value setting a configuration setting, which is left nil
+// unless the flag is given.
+type setting[T any] struct {
+	dst    **T
+	def    string // Default shown in the usage message
+	parse  func(string) (T, error)
+	isBool bool
+}
+
+func (s *setting[T]) String() string {
+	if s.dst == nil || *s.dst == nil {
+		return s.def
+	}
+
+	return fmt.Sprint(**s.dst)
+}
+
+func (s *setting[T]) Set(value string) error {
+	v, err := s.parse(value)
+	if err != nil {
+		return err
+	}
+
+	*s.dst = &v
+	return nil
+}
+
+func (s *setting[T]) IsBoolFlag() bool {
+	return s.isBool
+}
+
+// boolSetting returns the flag.Value of a boolean setting.
+func boolSetting(dst **bool) flag.Value {
+	return &setting[bool]{dst: dst, parse: strconv.ParseBool, isBool: true}
+}
+
+// stringSetting returns the flag.Value of a string setting, checking its
+// value with parse when it isn't nil.
+func stringSetting(dst **string, def string, parse func(string) (string, error)) flag.Value {
+	if parse == nil {
+		parse = func(s string) (string, error) { return s, nil }
+	}
+
+	return &setting[string]{dst: dst, def: def, parse: parse}
+}
+
+// parseCopyMode checks a copy mode.
+func parseCopyMode(s string) (string, error) {
+	mode, err := types.ParseCopyMode(s)
+	return string(mode), err
+}
+
+// parseDerefPolicy checks a dereference policy.
+func parseDerefPolicy(s string) (string, error) {
+	policy, err := types.ParseDerefPolicy(s)
+	return string(policy), err
+}
+
+// parseIdentifier checks that s is an identifier.
+func parseIdentifier(s string) (string, error) {
+	if !token.IsIdentifier(s) || s == "_" {
+		return "", fmt.Errorf("%q is not a valid identifier", s)
+	}
+
+	return s, nil
+}
+
+// splitList splits a comma-separated list, trimming spaces around its items.
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	items := strings.Split(list, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+
+	return items
+}
@@ -0,0 +1,343 @@
+// Package options runs go-getters on a package from the options shared by the
+// command line and library users, so that both select structs, apply the
+// configuration file and resolve the output file the same way.
+package options
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/config"
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/generator"
+	"github.com/renxzen/go-getters/pkg/parser"
+	"github.com/renxzen/go-getters/pkg/selector"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// DefaultOutput is the name of the generated file when neither the options
+// nor the configuration file give one.
+const DefaultOutput = "getters.gen.go"
+
+// ErrNoStructs is returned when no structs are given to generate getters for.
+var ErrNoStructs = errors.New("no structs given: use -structs, -all, include structs in the configuration file or declare one right after the go:generate directive")
+
+// Options describe a run of go-getters on a package.
+type Options struct {
+	// Input is the directory of the package, "." if empty.
+	Input string
+	// Output is the path of the generated file, relative to the input
+	// directory unless it is absolute. If empty, the getters of the struct
+	// selected by a go:generate directive are written to a file named after
+	// the directive's file and the struct, else the output of the
+	// configuration file is used, else DefaultOutput. When it is in another directory, the
+	// getters are generated as functions of the package of that directory,
+	// which imports the input package.
+	Output string
+	// Config is the path of the configuration file. If empty, it is looked up
+	// in the input directory and its parents.
+	Config string
+	// PackageName is the name of the package to parse, for directories holding
+	// an external test package. If empty, every file is parsed.
+	PackageName string
+
+	// GoFile and GoLine locate the go:generate directive running go-getters,
+	// GoFile being relative to the input directory. When no structs are given,
+	// the struct declared right after the directive is selected, if any.
+	GoFile string
+	GoLine int
+
+	// Structs lists struct names and path.Match patterns such as "*Request".
+	// If empty and All is not set, the structs included by the configuration
+	// file are selected.
+	Structs []string
+	// All selects every struct of the package.
+	All bool
+	// Exclude lists regular expressions matching the structs never selected.
+	Exclude []*regexp.Regexp
+	// Visibility keeps only exported or unexported structs. Empty keeps both.
+	Visibility selector.Visibility
+	// Lenient reports names and patterns matching no struct as warnings.
+	Lenient bool
+
+	// NullableTypes registers nullable wrapper types to unwrap.
+	NullableTypes []types.NullableType
+	// TemplateFiles renders the output with text/template files.
+	TemplateFiles []string
+	// ValueReceivers lists the structs whose getters use value receivers.
+	ValueReceivers []string
+	// Settings override those of the configuration file. The copy mode and
+	// receiver name also take precedence over getter tags and directives.
+	Settings config.Settings
+}
+
+// Result is the outcome of Generate.
+type Result struct {
+	// Path is the path of the output file.
+	Path string
+	// Code is the generated code, nil if no struct was selected.
+	Code []byte
+	// Structs lists the selected structs.
+	Structs []string
+	// Diagnostics lists the issues found in the source and while generating.
+	Diagnostics []diag.Diagnostic
+}
+
+// Generate generates the getters described by the options, without writing
+// them. The result holds the diagnostics found so far, even when an error is
+// returned.
+func Generate(o *Options) (*Result, error) {
+	res := &Result{}
+	var diags diag.List
+	finish := func(err error) (*Result, error) {
+		res.Diagnostics = diags.All()
+		return res, err
+	}
+
+	if err := o.Settings.Validate(); err != nil {
+		return finish(err)
+	}
+
+	pkg, err := o.Package()
+	if err != nil {
+		return finish(err)
+	}
+	directive, err := o.directiveStruct()
+	if err != nil {
+		return finish(err)
+	}
+	res.Path = o.outputPath(pkg, directive)
+
+	patterns := o.patterns(pkg, directive)
+	if len(patterns) == 0 && !o.All {
+		return finish(ErrNoStructs)
+	}
+
+	// Parse the directory to extract struct information. Test files are only
+	// visible to a test output file.
+	p := parser.New()
+	p.IncludeTests(strings.HasSuffix(res.Path, "_test.go"))
+	result, err := p.ParsePackage(o.input(), o.PackageName)
+	if err != nil {
+		return finish(fmt.Errorf("failed to parse directory: %w", err))
+	}
+	diags.Append(p.Diagnostics()...)
+
+	// Select the structs
+	sel := o.selector(pkg, patterns)
+	structs, warnings, err := sel.Select(result.Structs)
+	if err != nil {
+		return finish(fmt.Errorf("failed to select structs: %w", err))
+	}
+	for _, warning := range warnings {
+		diags.Warnf(token.Position{}, diag.UnmatchedPattern, "%s", warning)
+	}
+	if len(structs) == 0 {
+		if o.Lenient {
+			diags.Warnf(token.Position{}, diag.UnmatchedPattern, "no structs selected, nothing generated")
+			return finish(nil)
+		}
+		return finish(errors.New("failed to select structs: no structs selected"))
+	}
+	res.Structs = structs
+
+	opts := o.generatorOptions(pkg, structs)
+	outputPackage, sourcePath, err := o.outputPackage(res.Path, result.PackageName)
+	if err != nil {
+		return finish(fmt.Errorf("failed to resolve the output package: %w", err))
+	}
+	if outputPackage != "" {
+		opts = append(opts, generator.WithOutputPackage(outputPackage, sourcePath))
+	}
+
+	gen := generator.New(opts...)
+	res.Code, err = gen.GenerateGetters(structs, result)
+	diags.Append(gen.Diagnostics()...)
+	if err != nil {
+		res.Code = nil
+		return finish(fmt.Errorf("failed to generate getters: %w", err))
+	}
+
+	return finish(nil)
+}
+
+// Package returns the settings of the configuration file for the input
+// directory, empty if there is no configuration file.
+func (o *Options) Package() (config.Package, error) {
+	var cfg *config.Config
+	var err error
+	if o.Config != "" {
+		cfg, err = config.Load(o.Config)
+	} else {
+		cfg, err = config.Find(o.input())
+	}
+	if err != nil || cfg == nil {
+		return config.Package{}, err
+	}
+
+	pkg, err := cfg.PackageAt(o.input())
+	if err != nil {
+		return config.Package{}, fmt.Errorf("failed to resolve configuration: %w", err)
+	}
+
+	return pkg, nil
+}
+
+// OutputPath returns the path of the output file.
+func (o *Options) OutputPath() (string, error) {
+	pkg, err := o.Package()
+	if err != nil {
+		return "", err
+	}
+
+	directive, err := o.directiveStruct()
+	if err != nil {
+		return "", err
+	}
+
+	return o.outputPath(pkg, directive), nil
+}
+
+// input returns the directory of the package.
+func (o *Options) input() string {
+	return cmp.Or(o.Input, ".")
+}
+
+// outputPath returns the path of the output file, relative paths being
+// relative to the input directory. Without an output in the options, the
+// getters of the struct selected by a bare go:generate directive are written
+// to a file named after the directive's file and the struct, such as
+// "user_account_getters.gen.go", so that several directives of a package
+// don't overwrite each other's file.
+func (o *Options) outputPath(pkg config.Package, directive string) string {
+	output := o.Output
+	if output == "" && directive != "" {
+		output = directiveOutput(o.GoFile, directive)
+	}
+	if output == "" && pkg.Output != nil {
+		output = *pkg.Output
+	}
+	output = filepath.FromSlash(cmp.Or(output, DefaultOutput))
+
+	if filepath.IsAbs(output) {
+		return filepath.Clean(output)
+	}
+	return filepath.Join(o.input(), output)
+}
+
+// ReadGoGenerateEnv sets the package name and the location of the
+// go:generate directive from the environment set by go generate, read with
+// getenv, unless they are already set. The environment describes the package
+// in the current directory, so it is ignored when the input is another one.
+func (o *Options) ReadGoGenerateEnv(getenv func(string) string) {
+	if filepath.Clean(o.input()) != "." {
+		return
+	}
+
+	o.PackageName = cmp.Or(o.PackageName, getenv("GOPACKAGE"))
+	if o.GoFile == "" {
+		o.GoFile = getenv("GOFILE")
+		o.GoLine, _ = strconv.Atoi(getenv("GOLINE"))
+	}
+}
+
+// directiveOutput returns the name of the file holding the getters of the
+// struct declared after the go:generate directive of goFile. The file is a
+// test file when goFile is one.
+func directiveOutput(goFile, structName string) string {
+	base := strings.TrimSuffix(filepath.Base(goFile), ".go")
+	if base, ok := strings.CutSuffix(base, "_test"); ok {
+		return base + "_" + strings.ToLower(structName) + "_getters.gen_test.go"
+	}
+
+	return base + "_" + strings.ToLower(structName) + "_getters.gen.go"
+}
+
+// directiveStruct returns the name of the struct declared right after the
+// go:generate directive when it selects the struct, that is when no structs
+// are given and All is not set. It returns an empty string otherwise.
+func (o *Options) directiveStruct() (string, error) {
+	if len(o.Structs) > 0 || o.All || o.GoFile == "" || o.GoLine <= 0 {
+		return "", nil
+	}
+
+	name, err := parser.StructAfter(filepath.Join(o.input(), o.GoFile), o.GoLine)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the struct after the go:generate directive: %w", err)
+	}
+
+	return name, nil
+}
+
+// patterns returns the structs given by the options. When there are none and
+// All is not set, it returns the struct selected by the go:generate
+// directive if there is one, else those included by the configuration.
+func (o *Options) patterns(pkg config.Package, directive string) []string {
+	if len(o.Structs) > 0 || o.All {
+		return o.Structs
+	}
+
+	if directive != "" {
+		return []string{directive}
+	}
+
+	return pkg.Include
+}
+
+// selector returns the selector of the structs matching the patterns.
+func (o *Options) selector(pkg config.Package, patterns []string) *selector.Selector {
+	return &selector.Selector{
+		All:            o.All,
+		Patterns:       patterns,
+		Exclude:        pkg.Exclude,
+		ExcludeRegexps: o.Exclude,
+		Visibility:     o.Visibility,
+		Lenient:        o.Lenient,
+	}
+}
+
+// generatorOptions returns the generator options of the run. The
+// configuration is applied first, so that the settings of the options take
+// precedence over it, including for single structs.
+func (o *Options) generatorOptions(pkg config.Package, structs []string) []generator.Option {
+	given := o.settingsOptions()
+
+	opts := []generator.Option{
+		generator.WithNullableTypes(o.NullableTypes...),
+		generator.WithTemplateFiles(o.TemplateFiles...),
+	}
+	opts = append(opts, pkg.Settings.Options("")...)
+	opts = append(opts, given...)
+	for _, name := range structs {
+		settings := pkg.Struct(name)
+		opts = append(opts, generator.WithStructOptions(name, slices.Concat(settings.Options(name), given)...))
+	}
+
+	return opts
+}
+
+// settingsOptions returns the generator options of the settings given by the
+// options, with the copy mode and receiver name as overrides.
+func (o *Options) settingsOptions() []generator.Option {
+	opts := o.Settings.Options("")
+	if len(o.ValueReceivers) > 0 {
+		opts = append(opts, generator.WithValueReceivers(o.ValueReceivers...))
+	}
+
+	var overrides generator.Overrides
+	if o.Settings.Copy != nil {
+		overrides.CopyMode = types.CopyMode(*o.Settings.Copy)
+	}
+	if o.Settings.Receiver != nil {
+		overrides.Receiver = *o.Settings.Receiver
+	}
+
+	return append(opts, generator.WithOverrides(overrides))
+}
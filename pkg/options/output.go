@@ -0,0 +1,130 @@
+package options
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/parser"
+)
+
+// outputPackage returns the name of the package of the output file and the
+// import path of the source package, named sourceName, when the output file
+// is in another directory than the input one. Both are empty otherwise.
+// The output package is the one declared by the files of its directory, else
+// the one named after the directory.
+func (o *Options) outputPackage(outputPath, sourceName string) (name, sourcePath string, err error) {
+	input, err := filepath.Abs(o.input())
+	if err != nil {
+		return "", "", err
+	}
+	outputDir, err := filepath.Abs(filepath.Dir(outputPath))
+	if err != nil {
+		return "", "", err
+	}
+	if input == outputDir {
+		return "", "", nil
+	}
+
+	if sourceName == "main" {
+		return "", "", errors.New("package main can't be imported by the package of the output file")
+	}
+
+	name, err = parser.PackageName(outputDir)
+	if err != nil {
+		return "", "", err
+	}
+	if name == "" {
+		name = packageNameOf(outputDir)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("can't name the package of %s after its directory: add a Go file declaring it", outputDir)
+	}
+
+	sourcePath, err = importPath(input)
+	if err != nil {
+		return "", "", err
+	}
+
+	return name, sourcePath, nil
+}
+
+// packageNameOf returns the package name derived from the name of a
+// directory, lowercased and without the characters not allowed in names, or
+// an empty string if nothing is left.
+func packageNameOf(dir string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return -1
+		}
+	}, filepath.Base(dir))
+
+	if !token.IsIdentifier(name) || token.IsKeyword(name) || name == "_" {
+		return ""
+	}
+
+	return name
+}
+
+// importPath returns the import path of the package in the directory dir,
+// made of the path of the module declared by the closest go.mod file and of
+// the directory relative to it.
+func importPath(dir string) (string, error) {
+	for moduleDir := dir; ; moduleDir = filepath.Dir(moduleDir) {
+		modulePath, err := readModulePath(filepath.Join(moduleDir, "go.mod"))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if modulePath != "" {
+			rel, err := filepath.Rel(moduleDir, dir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), nil
+		}
+
+		if filepath.Dir(moduleDir) == moduleDir {
+			return "", fmt.Errorf("failed to find the import path of %s: no go.mod file in it or its parents", dir)
+		}
+	}
+}
+
+// readModulePath returns the module path declared by a go.mod file.
+func readModulePath(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		modulePath, ok := strings.CutPrefix(strings.TrimSpace(line), "module")
+		if !ok || modulePath == "" || modulePath[0] != ' ' && modulePath[0] != '\t' {
+			continue
+		}
+
+		modulePath = strings.TrimSpace(modulePath)
+		if unquoted, err := strconv.Unquote(modulePath); err == nil {
+			modulePath = unquoted
+		}
+		return modulePath, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("%s declares no module", filename)
+}
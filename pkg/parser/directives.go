@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// DirectivePrefix starts the comment directives read by the parser,
+// e.g. "//getters:unguarded". Like other Go directives, it has no space
+// after the slashes.
+const DirectivePrefix = "//getters:"
+
+// directive is a comment directive, without its prefix.
+type directive struct {
+	text string
+	pos  token.Pos
+}
+
+// parseDirectives returns the directives found in the comment groups.
+func parseDirectives(groups ...*ast.CommentGroup) []directive {
+	var directives []directive
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, comment := range group.List {
+			if text, ok := strings.CutPrefix(comment.Text, DirectivePrefix); ok {
+				directives = append(directives, directive{text: strings.TrimSpace(text), pos: comment.Pos()})
+			}
+		}
+	}
+
+	return directives
+}
+
+// parseStructDirectives applies the directives found in the struct's doc
+// comment, such as "//getters:value" or "//getters:receiver=u". Unknown
+// directives are reported and ignored.
+func (p *Parser) parseStructDirectives(structInfo *types.StructInfo, groups ...*ast.CommentGroup) {
+	for _, d := range parseDirectives(groups...) {
+		key, value, _ := strings.Cut(d.text, "=")
+		switch key {
+		case "value":
+			structInfo.ValueReceiver = true
+		case "receiver":
+			structInfo.Receiver = strings.TrimSpace(value)
+		default:
+			p.diags.Warnf(p.fset.Position(d.pos), diag.UnknownOption, "unknown directive %q on struct %s", DirectivePrefix+d.text, structInfo.Name)
+		}
+	}
+}
+
+// parseFieldDirectives applies the directives found in the field's doc and line
+// comments. Unknown directives are reported and ignored.
+func (p *Parser) parseFieldDirectives(fieldInfo *types.FieldInfo, groups ...*ast.CommentGroup) {
+	for _, d := range parseDirectives(groups...) {
+		switch d.text {
+		case "unguarded":
+			fieldInfo.Unguarded = true
+		default:
+			p.diags.Warnf(p.fset.Position(d.pos), diag.UnknownOption, "unknown directive %q on field %s", DirectivePrefix+d.text, fieldInfo.Name)
+		}
+	}
+}
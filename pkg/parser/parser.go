@@ -1,19 +1,28 @@
 package parser
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	gotypes "go/types"
+	"io/fs"
+	"maps"
+	"slices"
 	"strings"
 
+	"github.com/renxzen/go-getters/pkg/diag"
 	"github.com/renxzen/go-getters/pkg/strutils"
 	"github.com/renxzen/go-getters/pkg/types"
 )
 
 // Parser handles parsing of Go source files.
 type Parser struct {
-	fset *token.FileSet
+	fset  *token.FileSet
+	diags diag.List
+	tests bool // Whether _test.go files are parsed
 }
 
 // New creates a new Parser instance.
@@ -23,15 +32,47 @@ func New() *Parser {
 	}
 }
 
-// ParseDirectory parses all Go files in the specified directory and returns struct information.
+// Diagnostics returns the issues found in the parsed source, such as unknown
+// getter tag options and directives.
+func (p *Parser) Diagnostics() []diag.Diagnostic {
+	return p.diags.All()
+}
+
+// IncludeTests makes the parser read _test.go files too. They are skipped by
+// default, since their declarations can't be used by other files.
+func (p *Parser) IncludeTests(include bool) {
+	p.tests = include
+}
+
+// ParseDirectory parses the Go files in the specified directory and returns struct information.
 func (p *Parser) ParseDirectory(path string) (*types.ParseResult, error) {
-	pkgs, err := parser.ParseDir(p.fset, path, nil, parser.ParseComments)
+	return p.ParsePackage(path, "")
+}
+
+// ParsePackage parses the Go files of the named package in the specified
+// directory, such as the external test package "models_test" rather than
+// "models", and returns struct information. An empty name parses every file.
+// Only the structs declared at the top level are returned.
+func (p *Parser) ParsePackage(path, name string) (*types.ParseResult, error) {
+	filter := func(info fs.FileInfo) bool {
+		return p.tests || !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(p.fset, path, filter, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse directory %s: %w", path, err)
 	}
+	if name != "" {
+		pkg, exists := pkgs[name]
+		if !exists {
+			return nil, fmt.Errorf("no package %s in directory %s", name, path)
+		}
+		pkgs = map[string]*ast.Package{name: pkg}
+	}
 
 	imports := make(map[string]*types.ImportInfo)
 	structs := make(map[string]*types.StructInfo)
+	namedTypes := make(map[string]*types.TypeInfo)
+	methods := make(map[string][]types.MethodInfo)
 
 	var packageName string
 	for _, pkg := range pkgs {
@@ -39,45 +80,107 @@ func (p *Parser) ParseDirectory(path string) (*types.ParseResult, error) {
 			packageName = pkg.Name
 		}
 
-		for _, file := range pkg.Files {
+		// Files are parsed in a stable order, so that methods keep theirs
+		for _, fileName := range slices.Sorted(maps.Keys(pkg.Files)) {
+			file := pkg.Files[fileName]
 			p.parseImports(file, imports)
 
-			ast.Inspect(file, func(n ast.Node) bool {
-				typeSpec, ok := n.(*ast.TypeSpec)
-				if !ok {
-					return true
+			// Only top-level types are visible to the generated file
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
 				}
 
-				structType, ok := typeSpec.Type.(*ast.StructType)
-				if !ok {
-					return true
-				}
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok || typeSpec.Assign.IsValid() {
+						typeInfo := p.parseNamedType(typeSpec)
+						namedTypes[typeInfo.Name] = typeInfo
+						continue
+					}
 
-				structInfo := p.parseStruct(typeSpec.Name.Name, structType)
-				structs[structInfo.Name] = structInfo
+					structInfo := p.parseStruct(typeSpec.Name.Name, structType, imports)
+					structInfo.Position = p.fset.Position(typeSpec.Name.Pos())
+					p.parseStructDirectives(structInfo, typeDoc(genDecl, typeSpec))
+					structs[structInfo.Name] = structInfo
+				}
+			}
 
-				return true
-			})
+			// Methods generated by a previous run are replaced by this one
+			if !isGenerated(file) {
+				p.parseMethods(file, methods)
+			}
 		}
 	}
 
+	for name, structInfo := range structs {
+		structInfo.Methods = methods[name]
+	}
+	markNoCopyFields(structs, imports)
+
 	return &types.ParseResult{
 		PackageName: packageName,
 		Structs:     structs,
 		Imports:     imports,
+		Types:       namedTypes,
 	}, nil
 }
 
+// parseNamedType describes a named type that is not a struct, or an alias.
+func (p *Parser) parseNamedType(typeSpec *ast.TypeSpec) *types.TypeInfo {
+	typeInfo := &types.TypeInfo{
+		Name:     typeSpec.Name.Name,
+		Position: p.fset.Position(typeSpec.Name.Pos()),
+	}
+
+	if typeSpec.Assign.IsValid() {
+		typeInfo.Target = p.parseExprType(typeSpec.Type)
+		typeInfo.Kind = "an alias of " + typeInfo.Target
+		return typeInfo
+	}
+
+	switch t := typeSpec.Type.(type) {
+	case *ast.InterfaceType:
+		typeInfo.Kind = "an interface type"
+	case *ast.FuncType:
+		typeInfo.Kind = "a func type"
+	case *ast.ChanType:
+		typeInfo.Kind = "a channel type"
+	case *ast.MapType:
+		typeInfo.Kind = "a map type"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			typeInfo.Kind = "a slice type"
+		} else {
+			typeInfo.Kind = "an array type"
+		}
+	default:
+		typeInfo.Kind = "a type defined as " + p.parseExprType(typeSpec.Type)
+	}
+
+	return typeInfo
+}
+
 // parseStruct parses a single struct and returns its information.
-func (p *Parser) parseStruct(structName string, structType *ast.StructType) *types.StructInfo {
+func (p *Parser) parseStruct(structName string, structType *ast.StructType, imports map[string]*types.ImportInfo) *types.StructInfo {
 	structInfo := &types.StructInfo{
 		Name:   structName,
 		Fields: make([]types.FieldInfo, 0, len(structType.Fields.List)),
 	}
 
 	for _, field := range structType.Fields.List {
-		// Skip fields without names (embedded fields)
+		// Embedded fields are named after their type. They never get getters,
+		// but they can be the struct's lock or make it unsafe to copy.
 		if len(field.Names) == 0 {
+			fieldInfo := p.parseFieldType("", field.Type)
+			fieldInfo.Name = embeddedFieldName(fieldInfo.UnderlyingType)
+			fieldInfo.Position = p.fset.Position(field.Type.Pos())
+			fieldInfo.IsExported = strutils.IsCapitalized(fieldInfo.Name)
+			fieldInfo.IsEmbedded = true
+			fieldInfo.Lock = syncLockKind(fieldInfo.UnderlyingType, imports)
+			structInfo.Fields = append(structInfo.Fields, fieldInfo)
 			continue
 		}
 
@@ -85,12 +188,158 @@ func (p *Parser) parseStruct(structName string, structType *ast.StructType) *typ
 
 		// Parse field type information
 		fieldInfo := p.parseFieldType(fieldName, field.Type)
+		fieldInfo.Position = p.fset.Position(field.Names[0].Pos())
+		fieldInfo.Lock = syncLockKind(fieldInfo.UnderlyingType, imports)
+		parseAtomicType(&fieldInfo, imports)
+		p.parseFieldTag(field.Tag, &fieldInfo)
+		p.parseFieldDirectives(&fieldInfo, field.Doc, field.Comment)
+		fieldInfo.Doc = fieldDoc(field)
+		fieldInfo.Deprecated = deprecationNotice(fieldInfo.Doc)
 		structInfo.Fields = append(structInfo.Fields, fieldInfo)
 	}
 
 	return structInfo
 }
 
+// typeDoc returns the doc comment of a type declaration, which belongs to the
+// declaration itself unless it is grouped with others in parentheses.
+func typeDoc(genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) *ast.CommentGroup {
+	if typeSpec.Doc == nil && !genDecl.Lparen.IsValid() {
+		return genDecl.Doc
+	}
+
+	return typeSpec.Doc
+}
+
+// StructAfter returns the name of the struct declared by the first top-level
+// declaration of the file after the given line, such as the line of a
+// go:generate directive, or an empty string if that declaration doesn't
+// declare a struct.
+func StructAfter(filename string, line int) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+
+	for _, decl := range file.Decls {
+		if fset.Position(decl.Pos()).Line <= line {
+			continue
+		}
+
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			return "", nil
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if _, isStruct := typeSpec.Type.(*ast.StructType); isStruct && !typeSpec.Assign.IsValid() {
+				return typeSpec.Name.Name, nil
+			}
+		}
+
+		return "", nil
+	}
+
+	return "", nil
+}
+
+// PackageName returns the name of the package declared by the Go files of a
+// directory, ignoring external test packages, or an empty string if there is
+// none or the directory doesn't exist.
+func PackageName(dir string) (string, error) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.PackageClauseOnly)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to parse directory %s: %w", dir, err)
+	}
+
+	names := slices.Sorted(maps.Keys(pkgs))
+	names = slices.DeleteFunc(names, func(name string) bool { return strings.HasSuffix(name, "_test") })
+	switch len(names) {
+	case 0:
+		return "", nil
+	case 1:
+		return names[0], nil
+	default:
+		return "", fmt.Errorf("directory %s holds several packages: %s", dir, strings.Join(names, ", "))
+	}
+}
+
+// isGenerated reports whether the file was generated by go-getters.
+func isGenerated(file *ast.File) bool {
+	return len(file.Comments) > 0 && file.Comments[0].List[0].Text == types.GeneratedHeader
+}
+
+// parseMethods adds the methods declared in the file to methods, by the name
+// of their receiver's type.
+func (p *Parser) parseMethods(file *ast.File, methods map[string][]types.MethodInfo) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+
+		recvType := funcDecl.Recv.List[0].Type
+		star, isPointer := recvType.(*ast.StarExpr)
+		if isPointer {
+			recvType = star.X
+		}
+		baseType, _ := genericTypeParts(recvType)
+		ident, ok := baseType.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		var receiver string
+		if names := funcDecl.Recv.List[0].Names; len(names) > 0 {
+			receiver = names[0].Name
+		}
+
+		methods[ident.Name] = append(methods[ident.Name], types.MethodInfo{
+			Name:      funcDecl.Name.Name,
+			Receiver:  receiver,
+			IsPointer: isPointer,
+			Position:  p.fset.Position(funcDecl.Name.Pos()),
+		})
+	}
+}
+
+// fieldDoc returns the text of a field's doc comment, or of its line comment
+// if it has none. Directives are not part of the text.
+func fieldDoc(field *ast.Field) string {
+	if doc := strings.TrimSpace(field.Doc.Text()); doc != "" {
+		return doc
+	}
+
+	return strings.TrimSpace(field.Comment.Text())
+}
+
+// deprecationNotice returns the paragraph of a doc comment starting with
+// "Deprecated: ", or an empty string if there is none.
+func deprecationNotice(doc string) string {
+	for _, paragraph := range strings.Split(doc, "\n\n") {
+		if strings.HasPrefix(paragraph, types.DeprecatedPrefix) {
+			return paragraph
+		}
+	}
+
+	return ""
+}
+
+// embeddedFieldName returns the implicit name of an embedded field of the given type.
+func embeddedFieldName(typeName string) string {
+	typeName, _, _ = strings.Cut(typeName, "[")
+	if i := strings.LastIndex(typeName, "."); i >= 0 {
+		return typeName[i+1:]
+	}
+
+	return typeName
+}
+
 // parseFieldType parses field type information.
 func (p *Parser) parseFieldType(fieldName string, fieldType ast.Expr) types.FieldInfo {
 	fieldInfo := types.FieldInfo{
@@ -109,6 +358,9 @@ func (p *Parser) parseFieldType(fieldName string, fieldType ast.Expr) types.Fiel
 		// Preserve other properties from the underlying type
 		fieldInfo.IsSlice = underlyingField.IsSlice
 		fieldInfo.IsMap = underlyingField.IsMap
+		fieldInfo.Key = underlyingField.Key
+		fieldInfo.Elem = underlyingField.Elem
+		fieldInfo.Unsupported = underlyingField.Unsupported
 		for _, requiredImport := range underlyingField.RequiredImports {
 			fieldInfo.AddRequiredImport(requiredImport)
 		}
@@ -131,6 +383,8 @@ func (p *Parser) parseFieldType(fieldName string, fieldType ast.Expr) types.Fiel
 		// Recursively handle the element type
 		elementField := p.parseFieldType("", t.Elt)
 		fieldInfo.RequiredImports = elementField.RequiredImports
+		fieldInfo.Elem = &elementField
+		fieldInfo.Unsupported = elementField.Unsupported
 
 		// if Slices and Arrays need to be handled differently
 		// we need to check t.Len. if it is nil, it's a slice,
@@ -151,15 +405,46 @@ func (p *Parser) parseFieldType(fieldName string, fieldType ast.Expr) types.Fiel
 		for _, requiredImport := range valueField.RequiredImports {
 			fieldInfo.AddRequiredImport(requiredImport)
 		}
+		fieldInfo.Key = &keyField
+		fieldInfo.Elem = &valueField
+		fieldInfo.Unsupported = cmp.Or(keyField.Unsupported, valueField.Unsupported)
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		// Handle instantiated generic types
+		baseExpr, argExprs := genericTypeParts(t)
+		baseField := p.parseFieldType("", baseExpr)
+		fieldInfo.RequiredImports = baseField.RequiredImports
+
+		argTypes := make([]string, 0, len(argExprs))
+		for _, argExpr := range argExprs {
+			argField := p.parseFieldType("", argExpr)
+			argTypes = append(argTypes, argField.Type)
+			fieldInfo.Unsupported = cmp.Or(fieldInfo.Unsupported, argField.Unsupported)
+			for _, requiredImport := range argField.RequiredImports {
+				fieldInfo.AddRequiredImport(requiredImport)
+			}
+		}
+
+		fieldInfo.Type = baseField.Type + "[" + strings.Join(argTypes, ", ") + "]"
+		fieldInfo.UnderlyingType = fieldInfo.Type
 	default:
 		// Handle other complex types
 		fieldInfo.Type = "any"
 		fieldInfo.UnderlyingType = "any"
+		if !isEmptyInterface(t) {
+			fieldInfo.Unsupported = gotypes.ExprString(t)
+		}
 	}
 
 	return fieldInfo
 }
 
+// isEmptyInterface reports whether expr is interface{}, which any stands for
+// exactly.
+func isEmptyInterface(expr ast.Expr) bool {
+	iface, ok := expr.(*ast.InterfaceType)
+	return ok && len(iface.Methods.List) == 0
+}
+
 // parseExprType parses an expression and returns its string representation.
 // This method unifies the functionality of parseElementType and parseExprType.
 func (p *Parser) parseExprType(expr ast.Expr) string {
@@ -178,11 +463,30 @@ func (p *Parser) parseExprType(expr ast.Expr) string {
 		keyType := p.parseExprType(t.Key)
 		valueType := p.parseExprType(t.Value)
 		return fmt.Sprintf("map[%s]%s", keyType, valueType)
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		baseExpr, argExprs := genericTypeParts(t)
+		argTypes := make([]string, 0, len(argExprs))
+		for _, argExpr := range argExprs {
+			argTypes = append(argTypes, p.parseExprType(argExpr))
+		}
+		return p.parseExprType(baseExpr) + "[" + strings.Join(argTypes, ", ") + "]"
 	default:
 		return "any"
 	}
 }
 
+// genericTypeParts splits an instantiated generic type into its base type and type arguments.
+func genericTypeParts(expr ast.Expr) (ast.Expr, []ast.Expr) {
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		return t.X, []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		return t.X, t.Indices
+	default:
+		return expr, nil
+	}
+}
+
 // parseImports parses import declarations from an AST file and adds them to the imports map.
 func (p *Parser) parseImports(file *ast.File, imports map[string]*types.ImportInfo) {
 	for _, imp := range file.Imports {
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// atomicLoadTypes maps sync/atomic types to the type returned by their Load method.
+var atomicLoadTypes = map[string]string{
+	"Bool":    "bool",
+	"Int32":   "int32",
+	"Int64":   "int64",
+	"Uint32":  "uint32",
+	"Uint64":  "uint64",
+	"Uintptr": "uintptr",
+	"Value":   "any",
+}
+
+// noCopySyncTypes lists the sync types that must not be copied after first use.
+var noCopySyncTypes = map[string]bool{
+	"Cond":      true,
+	"Map":       true,
+	"Mutex":     true,
+	"Once":      true,
+	"Pool":      true,
+	"RWMutex":   true,
+	"WaitGroup": true,
+}
+
+// resolveType splits a package-qualified type name and returns the import path
+// of its package along with the type name, without type arguments.
+func resolveType(typeName string, imports map[string]*types.ImportInfo) (path, name string) {
+	alias, name, ok := strings.Cut(typeName, ".")
+	if !ok {
+		return "", ""
+	}
+
+	imp, exists := imports[alias]
+	if !exists {
+		return "", ""
+	}
+
+	name, _, _ = strings.Cut(name, "[")
+	return imp.Path, name
+}
+
+// syncLockKind returns the kind of lock for sync.Mutex and sync.RWMutex types,
+// resolving the package through the file imports.
+func syncLockKind(typeName string, imports map[string]*types.ImportInfo) types.LockKind {
+	path, name := resolveType(typeName, imports)
+	if path != "sync" {
+		return types.LockNone
+	}
+
+	switch name {
+	case "Mutex":
+		return types.LockMutex
+	case "RWMutex":
+		return types.LockRWMutex
+	default:
+		return types.LockNone
+	}
+}
+
+// parseAtomicType sets the AtomicType of fields holding a sync/atomic type.
+// Getters of such fields call Load, so the atomic package itself is no longer
+// a required import.
+func parseAtomicType(fieldInfo *types.FieldInfo, imports map[string]*types.ImportInfo) {
+	if fieldInfo.IsCollection() {
+		return
+	}
+
+	path, name := resolveType(fieldInfo.UnderlyingType, imports)
+	if path != "sync/atomic" {
+		return
+	}
+
+	alias, _, _ := strings.Cut(fieldInfo.UnderlyingType, ".")
+	if name == "Pointer" {
+		// atomic.Pointer[T] loads a *T
+		typeArg := strings.TrimPrefix(fieldInfo.UnderlyingType, alias+".Pointer[")
+		fieldInfo.AtomicType = "*" + strings.TrimSuffix(typeArg, "]")
+	} else if loadType, ok := atomicLoadTypes[name]; ok {
+		fieldInfo.AtomicType = loadType
+	} else {
+		return
+	}
+
+	requiredImports := fieldInfo.RequiredImports[:0]
+	for _, requiredImport := range fieldInfo.RequiredImports {
+		if requiredImport != alias {
+			requiredImports = append(requiredImports, requiredImport)
+		}
+	}
+	fieldInfo.RequiredImports = requiredImports
+}
+
+// markNoCopyFields sets NoCopy on the fields holding a value that must not be
+// copied: sync and sync/atomic types, noCopy markers, and structs of the
+// package containing any of them.
+func markNoCopyFields(structs map[string]*types.StructInfo, imports map[string]*types.ImportInfo) {
+	// Structs of the package that contain a no-copy value, resolved lazily
+	// since they can reference each other
+	noCopyStructs := make(map[string]bool)
+	visiting := make(map[string]bool)
+
+	var isNoCopy func(field types.FieldInfo) bool
+	var structIsNoCopy func(name string) bool
+
+	isNoCopy = func(field types.FieldInfo) bool {
+		if field.IsPointer || field.IsCollection() {
+			return false
+		}
+
+		if field.UnderlyingType == "noCopy" {
+			return true
+		}
+
+		switch path, name := resolveType(field.UnderlyingType, imports); path {
+		case "sync":
+			return noCopySyncTypes[name]
+		case "sync/atomic":
+			return true
+		case "":
+			return structIsNoCopy(field.UnderlyingType)
+		default:
+			return false
+		}
+	}
+
+	structIsNoCopy = func(name string) bool {
+		if result, ok := noCopyStructs[name]; ok {
+			return result
+		}
+
+		structInfo, ok := structs[name]
+		if !ok || visiting[name] {
+			return false
+		}
+
+		visiting[name] = true
+		result := false
+		for _, field := range structInfo.Fields {
+			if isNoCopy(field) {
+				result = true
+				break
+			}
+		}
+		visiting[name] = false
+
+		noCopyStructs[name] = result
+		return result
+	}
+
+	for _, structInfo := range structs {
+		for i := range structInfo.Fields {
+			structInfo.Fields[i].NoCopy = isNoCopy(structInfo.Fields[i])
+		}
+	}
+}
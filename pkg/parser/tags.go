@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/diag"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// TagKey is the struct tag key holding per-field getter options,
+// e.g. `getter:"deepcopy"`.
+const TagKey = "getter"
+
+// parseFieldTag applies the options found in the field's getter tag.
+// Options are comma-separated, unknown options are reported and ignored.
+// A "-" tag skips the field, and "name=X" renames its generated methods.
+func (p *Parser) parseFieldTag(tag *ast.BasicLit, fieldInfo *types.FieldInfo) {
+	if tag == nil {
+		return
+	}
+
+	rawTag, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return
+	}
+	fieldInfo.Tag = rawTag
+
+	value, ok := reflect.StructTag(rawTag).Lookup(TagKey)
+	if !ok {
+		return
+	}
+
+	if value == "-" {
+		fieldInfo.Skip = true
+		return
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		option = strings.TrimSpace(option)
+		if name, ok := strings.CutPrefix(option, "name="); ok {
+			if !token.IsIdentifier(name) {
+				p.diags.Warnf(p.fset.Position(tag.Pos()), diag.UnknownOption, "invalid name %q in getter tag of field %s", name, fieldInfo.Name)
+				continue
+			}
+			fieldInfo.Rename = name
+			continue
+		}
+
+		switch option {
+		case "copy":
+			fieldInfo.CopyMode = types.CopyShallow
+		case "deepcopy":
+			fieldInfo.CopyMode = types.CopyDeep
+		case "nocopy":
+			fieldInfo.CopyMode = types.CopyNone
+		case "lock":
+			fieldInfo.Lock = types.LockMutex
+		case "rwlock":
+			fieldInfo.Lock = types.LockRWMutex
+		default:
+			p.diags.Warnf(p.fset.Position(tag.Pos()), diag.UnknownOption, "unknown option %q in getter tag of field %s", option, fieldInfo.Name)
+		}
+	}
+}
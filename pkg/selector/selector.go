@@ -0,0 +1,171 @@
+// Package selector selects the structs of a package to generate getters for.
+package selector
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/strutils"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+// Visibility filters structs by whether their name is exported.
+type Visibility string
+
+const (
+	// VisibilityAll keeps exported and unexported structs.
+	VisibilityAll Visibility = "all"
+	// VisibilityExported keeps exported structs only.
+	VisibilityExported Visibility = "exported"
+	// VisibilityUnexported keeps unexported structs only.
+	VisibilityUnexported Visibility = "unexported"
+)
+
+// ParseVisibility converts a string into a Visibility.
+func ParseVisibility(s string) (Visibility, error) {
+	switch visibility := Visibility(s); visibility {
+	case VisibilityAll, VisibilityExported, VisibilityUnexported:
+		return visibility, nil
+	default:
+		return "", fmt.Errorf("invalid visibility %q, expected one of all, exported or unexported", s)
+	}
+}
+
+// Selector describes the structs to select.
+type Selector struct {
+	// All selects every struct of the package.
+	All bool
+	// Patterns lists struct names and path.Match patterns such as "*Request".
+	Patterns []string
+	// Exclude lists path.Match patterns of the structs never selected.
+	Exclude []string
+	// ExcludeRegexps lists regular expressions matching the structs never selected.
+	ExcludeRegexps []*regexp.Regexp
+	// Visibility keeps only exported or unexported structs. Empty keeps both.
+	Visibility Visibility
+	// Lenient reports unmatched patterns as warnings rather than errors.
+	Lenient bool
+}
+
+// Select returns the names of the selected structs, in the order of the
+// patterns matching them, with the structs matching a single pattern sorted
+// by name. Names are kept as given, even if the package has no such struct,
+// so that the generator reports them. Exclusions and the visibility filter
+// only narrow down patterns and the All mode.
+//
+// Patterns matching no struct, before exclusions and filters, are errors
+// unless the selector is lenient, in which case they are returned as warnings
+// along with missing names.
+func (s *Selector) Select(structs map[string]*types.StructInfo) (names []string, warnings []string, err error) {
+	var unmatched []string
+	selected := make(map[string]bool)
+	add := func(name string) {
+		if !selected[name] {
+			selected[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sortedNames := slices.Sorted(maps.Keys(structs))
+	if s.All {
+		for _, name := range sortedNames {
+			if s.keeps(name) {
+				add(name)
+			}
+		}
+	}
+
+	for _, pattern := range s.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, nil, fmt.Errorf("invalid struct pattern %q: %w", pattern, err)
+		}
+
+		if !isPattern(pattern) {
+			if _, exists := structs[pattern]; !exists && s.Lenient {
+				unmatched = append(unmatched, pattern)
+				continue
+			}
+
+			add(pattern)
+			continue
+		}
+
+		matched := false
+		for _, name := range sortedNames {
+			if ok, _ := path.Match(pattern, name); !ok {
+				continue
+			}
+
+			matched = true
+			if s.keeps(name) {
+				add(name)
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+
+	if len(unmatched) == 0 {
+		return names, nil, nil
+	}
+
+	if !s.Lenient {
+		return nil, nil, fmt.Errorf("no struct matches %s", quoteList(unmatched))
+	}
+
+	for _, pattern := range unmatched {
+		warnings = append(warnings, fmt.Sprintf("no struct matches %q", pattern))
+	}
+
+	return names, warnings, nil
+}
+
+// keeps reports whether a struct matched by a pattern or the All mode is
+// selected, given the exclusions and the visibility filter.
+func (s *Selector) keeps(name string) bool {
+	switch s.Visibility {
+	case VisibilityExported:
+		if !strutils.IsCapitalized(name) {
+			return false
+		}
+	case VisibilityUnexported:
+		if strutils.IsCapitalized(name) {
+			return false
+		}
+	}
+
+	for _, pattern := range s.Exclude {
+		if matched, _ := path.Match(pattern, name); matched {
+			return false
+		}
+	}
+
+	for _, re := range s.ExcludeRegexps {
+		if re.MatchString(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isPattern reports whether the pattern holds path.Match special characters,
+// rather than being a plain struct name.
+func isPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// quoteList quotes and joins names with commas.
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -1,6 +1,9 @@
 package strutils
 
-import "unicode"
+import (
+	"strings"
+	"unicode"
+)
 
 // Capitalize capitalizes the first letter of a string.
 func Capitalize(s string) string {
@@ -16,3 +19,44 @@ func Capitalize(s string) string {
 func IsCapitalized(name string) bool {
 	return len(name) > 0 && name[0] >= 'A' && name[0] <= 'Z'
 }
+
+// Singularize returns a best-effort singular form of an English plural noun,
+// e.g. "Items" becomes "Item" and "Entries" becomes "Entry". Words that do
+// not look like plurals are returned unchanged.
+func Singularize(s string) string {
+	switch {
+	case len(s) > 3 && strings.HasSuffix(s, "ies"):
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "ss"), strings.HasSuffix(s, "us"), strings.HasSuffix(s, "is"):
+		return s
+	case len(s) > 1 && strings.HasSuffix(s, "s"):
+		return s[:len(s)-1]
+	default:
+		return s
+	}
+}
+
+// Distance returns the Levenshtein distance between two strings, the number of
+// runes to insert, delete or substitute to turn a into b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
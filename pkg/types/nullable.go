@@ -0,0 +1,81 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NullableType describes a wrapper holding a value along with a flag reporting
+// whether it is set, like sql.NullString. Getters of such fields return the
+// value, or its zero value when unset, and Has<Field> methods report the flag.
+type NullableType struct {
+	Type       string // Qualified wrapper type, e.g. "database/sql.NullString". Generic wrappers omit their type arguments.
+	ValueField string // Field holding the value
+	ValueType  string // Qualified type of the value, e.g. "time.Time". Empty for generic wrappers holding their type argument.
+	ValidField string // Boolean field reporting whether the value is set
+}
+
+// DefaultNullableTypes returns the nullable wrappers recognised out of the box:
+// those of database/sql and of github.com/jackc/pgx/v5/pgtype.
+func DefaultNullableTypes() []NullableType {
+	const pgtype = "github.com/jackc/pgx/v5/pgtype"
+
+	return []NullableType{
+		{Type: "database/sql.Null", ValueField: "V", ValidField: "Valid"},
+		{Type: "database/sql.NullBool", ValueField: "Bool", ValueType: "bool", ValidField: "Valid"},
+		{Type: "database/sql.NullByte", ValueField: "Byte", ValueType: "byte", ValidField: "Valid"},
+		{Type: "database/sql.NullFloat64", ValueField: "Float64", ValueType: "float64", ValidField: "Valid"},
+		{Type: "database/sql.NullInt16", ValueField: "Int16", ValueType: "int16", ValidField: "Valid"},
+		{Type: "database/sql.NullInt32", ValueField: "Int32", ValueType: "int32", ValidField: "Valid"},
+		{Type: "database/sql.NullInt64", ValueField: "Int64", ValueType: "int64", ValidField: "Valid"},
+		{Type: "database/sql.NullString", ValueField: "String", ValueType: "string", ValidField: "Valid"},
+		{Type: "database/sql.NullTime", ValueField: "Time", ValueType: "time.Time", ValidField: "Valid"},
+		{Type: pgtype + ".Bool", ValueField: "Bool", ValueType: "bool", ValidField: "Valid"},
+		{Type: pgtype + ".Date", ValueField: "Time", ValueType: "time.Time", ValidField: "Valid"},
+		{Type: pgtype + ".Float4", ValueField: "Float32", ValueType: "float32", ValidField: "Valid"},
+		{Type: pgtype + ".Float8", ValueField: "Float64", ValueType: "float64", ValidField: "Valid"},
+		{Type: pgtype + ".Int2", ValueField: "Int16", ValueType: "int16", ValidField: "Valid"},
+		{Type: pgtype + ".Int4", ValueField: "Int32", ValueType: "int32", ValidField: "Valid"},
+		{Type: pgtype + ".Int8", ValueField: "Int64", ValueType: "int64", ValidField: "Valid"},
+		{Type: pgtype + ".Text", ValueField: "String", ValueType: "string", ValidField: "Valid"},
+		{Type: pgtype + ".Timestamp", ValueField: "Time", ValueType: "time.Time", ValidField: "Valid"},
+		{Type: pgtype + ".Timestamptz", ValueField: "Time", ValueType: "time.Time", ValidField: "Valid"},
+		{Type: pgtype + ".UUID", ValueField: "Bytes", ValueType: "[16]byte", ValidField: "Valid"},
+	}
+}
+
+// ParseNullableType parses a nullable wrapper in the form
+// "Type:ValueField[:ValueType[:ValidField]]", e.g.
+// "example.com/db.NullUUID:UUID:github.com/google/uuid.UUID".
+// ValidField defaults to "Valid".
+func ParseNullableType(s string) (NullableType, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 4 || parts[0] == "" || parts[1] == "" {
+		return NullableType{}, fmt.Errorf("invalid nullable type %q, expected Type:ValueField[:ValueType[:ValidField]]", s)
+	}
+
+	nullable := NullableType{
+		Type:       parts[0],
+		ValueField: parts[1],
+		ValidField: "Valid",
+	}
+	if len(parts) > 2 {
+		nullable.ValueType = parts[2]
+	}
+	if len(parts) > 3 && parts[3] != "" {
+		nullable.ValidField = parts[3]
+	}
+
+	return nullable, nil
+}
+
+// SplitQualifiedType splits a qualified type such as "github.com/google/uuid.UUID"
+// into its import path and type name. The path is empty for unqualified types.
+func SplitQualifiedType(qualifiedType string) (path, name string) {
+	i := strings.LastIndex(qualifiedType, ".")
+	if i < 0 || strings.Contains(qualifiedType[i:], "/") {
+		return "", qualifiedType
+	}
+
+	return qualifiedType[:i], qualifiedType[i+1:]
+}
@@ -1,16 +1,68 @@
 package types
 
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+
+	"github.com/renxzen/go-getters/pkg/strutils"
+)
+
 // ParseResult contains the parsing results including package name and structs.
 type ParseResult struct {
 	PackageName string
 	Structs     map[string]*StructInfo
 	Imports     map[string]*ImportInfo
+	Types       map[string]*TypeInfo // Named types of the package that are not structs, by name
+}
+
+// TypeInfo contains information about a named type that is not a struct.
+type TypeInfo struct {
+	Name     string
+	Kind     string         // Description of the type, such as "an alias of string" or "an interface type"
+	Target   string         // Type aliased by the name, empty if it isn't an alias
+	Position token.Position // Position of the type's name in its declaration
 }
 
 // StructInfo contains information about a struct.
 type StructInfo struct {
-	Name   string
-	Fields []FieldInfo
+	Name          string
+	Position      token.Position // Position of the struct's name in its declaration
+	Fields        []FieldInfo
+	Methods       []MethodInfo // Methods declared on the struct, except in files generated by go-getters
+	ValueReceiver bool         // Whether the getters use a value receiver, from the struct's directives
+	Receiver      string       // Name of the receiver of generated methods, from the struct's directives
+}
+
+// MethodInfo contains information about a method declared on a struct.
+type MethodInfo struct {
+	Name      string         // Method name
+	Receiver  string         // Name of the receiver, empty if it is unnamed
+	IsPointer bool           // Whether the method has a pointer receiver
+	Position  token.Position // Position of the method's name in its declaration
+}
+
+// GeneratedHeader is the first line of the files generated by go-getters.
+const GeneratedHeader = "// Code generated by go-getters. DO NOT EDIT."
+
+// IsGenerated reports whether src is the content of a file generated by
+// go-getters, whose first line is exactly GeneratedHeader.
+func IsGenerated(src []byte) bool {
+	line, _, _ := bytes.Cut(src, []byte("\n"))
+	return string(bytes.TrimSuffix(line, []byte("\r"))) == GeneratedHeader
+}
+
+// LockField returns the mutex field guarding the struct, or nil if it has none.
+func (s *StructInfo) LockField() *FieldInfo {
+	for i := range s.Fields {
+		if s.Fields[i].Lock != LockNone {
+			return &s.Fields[i]
+		}
+	}
+
+	return nil
 }
 
 type ImportInfo struct {
@@ -20,14 +72,88 @@ type ImportInfo struct {
 }
 
 type FieldInfo struct {
-	Name            string   // Field name
-	Type            string   // Field type as string
-	UnderlyingType  string   // Underlying type for pointers
-	IsPointer       bool     // Whether the field is a pointer
-	IsExported      bool     // Whether the field is exported
-	IsSlice         bool     // Whether the field is a slice
-	IsMap           bool     // Whether the field is a map
-	RequiredImports []string // Import aliases for package-qualified types. Can be more than one in case of maps.
+	Name            string         // Field name
+	Position        token.Position // Position of the field's name, or of its type if embedded
+	Unsupported     string         // Source of a type expression that is not supported and is referred to as any, empty if there is none
+	Tag             string         // Raw struct tag, without quotes
+	Doc             string         // Text of the field's doc comment, or of its line comment if it has none
+	Deprecated      string         // Deprecation notice paragraph of the field's comment, starting with DeprecatedPrefix
+	Rename          string         // Name used in generated method names instead of the field name, from the getter tag
+	Skip            bool           // Whether the getter tag excludes the field from generation
+	Type            string         // Field type as string
+	UnderlyingType  string         // Underlying type for pointers
+	IsPointer       bool           // Whether the field is a pointer
+	IsExported      bool           // Whether the field is exported
+	IsSlice         bool           // Whether the field is a slice
+	IsMap           bool           // Whether the field is a map
+	RequiredImports []string       // Import aliases for package-qualified types. Can be more than one in case of maps.
+	Key             *FieldInfo     // Key type of maps
+	Elem            *FieldInfo     // Element type of slices and value type of maps
+	CopyMode        CopyMode       // Copy mode requested by the field's getter tag, empty if unset
+	Lock            LockKind       // Kind of lock if the field is a mutex guarding the struct
+	IsEmbedded      bool           // Whether the field is embedded, named after its type
+	Unguarded       bool           // Whether the field is read and written without holding the struct's lock
+	AtomicType      string         // Type returned by Load for sync/atomic fields, empty otherwise
+	NoCopy          bool           // Whether the field holds a value that must not be copied, like a mutex
+}
+
+// DeprecatedPrefix starts the paragraph of a doc comment marking a deprecated identifier.
+const DeprecatedPrefix = "Deprecated: "
+
+// LockKind describes how a mutex field is locked.
+type LockKind string
+
+const (
+	// LockNone marks fields that are not locks.
+	LockNone LockKind = ""
+	// LockMutex locks with Lock/Unlock for both reads and writes.
+	LockMutex LockKind = "mutex"
+	// LockRWMutex locks with RLock/RUnlock for reads and Lock/Unlock for writes.
+	LockRWMutex LockKind = "rwmutex"
+)
+
+// CopyMode controls how getters for slice and map fields return their values.
+type CopyMode string
+
+const (
+	// CopyNone returns the backing slice or map as is.
+	CopyNone CopyMode = "none"
+	// CopyShallow returns a copy made with slices.Clone or maps.Clone.
+	CopyShallow CopyMode = "shallow"
+	// CopyDeep also copies the slices and maps nested inside the collection.
+	CopyDeep CopyMode = "deep"
+)
+
+// DerefPolicy controls whether getters of pointer fields return the value
+// they point to.
+type DerefPolicy string
+
+const (
+	// DerefAuto dereferences pointers to primitives and slices, returning their
+	// zero value when the pointer is nil.
+	DerefAuto DerefPolicy = "auto"
+	// DerefNever returns pointers as they are.
+	DerefNever DerefPolicy = "never"
+)
+
+// ParseDerefPolicy converts a string into a DerefPolicy.
+func ParseDerefPolicy(s string) (DerefPolicy, error) {
+	switch policy := DerefPolicy(s); policy {
+	case DerefAuto, DerefNever:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid deref policy %q, expected auto or never", s)
+	}
+}
+
+// ParseCopyMode converts a string into a CopyMode.
+func ParseCopyMode(s string) (CopyMode, error) {
+	switch mode := CopyMode(s); mode {
+	case CopyNone, CopyShallow, CopyDeep:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid copy mode %q, expected one of none, shallow or deep", s)
+	}
 }
 
 func (f FieldInfo) IsPrimitive() bool {
@@ -62,7 +188,7 @@ func (f FieldInfo) GetZerovalue() string {
 		return `""`
 	case "int", "int8", "int16", "int32", "int64":
 		return "0"
-	case "uint", "uint8", "uint16", "uint32", "uint64":
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
 		return "0"
 	case "float32", "float64":
 		return "0.0"
@@ -72,6 +198,8 @@ func (f FieldInfo) GetZerovalue() string {
 		return "0"
 	case "rune":
 		return "0"
+	case "any":
+		return "nil"
 	default:
 		// For custom types and package-qualified types, use the zero value syntax
 		// This works for structs, interfaces, and other custom types
@@ -79,6 +207,28 @@ func (f FieldInfo) GetZerovalue() string {
 	}
 }
 
+// IsCollection reports whether the field is a slice or a map, either directly or through a pointer.
+func (f FieldInfo) IsCollection() bool {
+	return f.IsSlice || f.IsMap
+}
+
+// MethodName returns the name used in the field's generated methods, such as
+// "Name" in GetName, honouring renames from the getter tag.
+func (f FieldInfo) MethodName() string {
+	if f.Rename != "" {
+		return f.Rename
+	}
+
+	return strutils.Capitalize(f.Name)
+}
+
+// JSONName returns the name given to the field by its json tag, or an empty
+// string if it has none.
+func (f FieldInfo) JSONName() string {
+	name, _, _ := strings.Cut(reflect.StructTag(f.Tag).Get("json"), ",")
+	return name
+}
+
 func (f *FieldInfo) AddRequiredImport(alias string) {
 	if alias == "" {
 		return
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/renxzen/go-getters/pkg/clean"
+	"github.com/renxzen/go-getters/pkg/types"
+)
+
+func TestCleanFind(t *testing.T) {
+	root := t.TempDir()
+	header := types.GeneratedHeader + "\n\npackage models\n"
+	files := map[string]string{
+		"getters.gen.go":             header,
+		"old/getters.go":             header,
+		"crlf.go":                    types.GeneratedHeader + "\r\n\r\npackage models\r\n",
+		"models.go":                  "package models\n",
+		"stringer.go":                "// Code generated by \"stringer -type=Kind\"; DO NOT EDIT.\n\npackage models\n",
+		"longer.go":                  types.GeneratedHeader + " Or maybe not.\npackage models\n",
+		"second_line.go":             "// Package models.\n" + header,
+		"getters.gen.go.bak":         header,
+		"vendor/lib/getters.gen.go":  header,
+		"testdata/getters.gen.go":    header,
+		".cache/getters.gen.go":      header,
+		"_old/getters.gen.go":        header,
+		"nested/deeper/accessors.go": header,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := clean.Find(root)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	var want []string
+	for _, name := range []string{"crlf.go", "getters.gen.go", "nested/deeper/accessors.go", "old/getters.go"} {
+		want = append(want, filepath.Join(root, name))
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Find = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/renxzen/go-getters/pkg/config"
+	"github.com/renxzen/go-getters/pkg/generator"
+	"github.com/renxzen/go-getters/pkg/parser"
+	"github.com/renxzen/go-getters/pkg/selector"
+)
+
+func TestConfigFile(t *testing.T) {
+	dir := filepath.Join("testdata", "config", "models")
+	cfg, err := config.Find(dir)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if cfg == nil || filepath.Base(filepath.Dir(cfg.Path)) != "config" {
+		t.Fatalf("Find returned %v, want the configuration of the parent directory", cfg)
+	}
+
+	pkg, err := cfg.PackageAt(dir)
+	if err != nil {
+		t.Fatalf("PackageAt failed: %v", err)
+	}
+
+	p := parser.New()
+	result, err := p.ParseDirectory("testdata")
+	if err != nil {
+		t.Fatalf("Failed to parse directory: %v", err)
+	}
+
+	sel := selector.Selector{Patterns: pkg.Include, Exclude: pkg.Exclude}
+	selected, _, err := sel.Select(result.Structs)
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if want := []string{"Money"}; !slices.Equal(selected, want) {
+		t.Errorf("Selected structs = %q, want %q", selected, want)
+	}
+
+	settings := pkg.Struct("Money")
+	gen := generator.New(
+		generator.WithStructOptions("Money", settings.Options("Money")...),
+	)
+	outBytes, err := gen.GenerateGetters([]string{"Money"}, result)
+	if err != nil {
+		t.Fatalf("GenerateGetters failed: %v", err)
+	}
+
+	goldenPath := filepath.Join("testdata", "config_file.golden")
+	if *update {
+		if err := os.WriteFile(goldenPath, outBytes, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to read golden file %s: %v", goldenPath, err)
+	}
+	if !bytes.Equal(outBytes, expected) {
+		t.Errorf("Generated output doesn't match golden file %s", goldenPath)
+		t.Errorf("Expected:\n%s", string(expected))
+		t.Errorf("Got:\n%s", string(outBytes))
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	configPath := filepath.Join("testdata", "config", "invalid", config.FileName)
+	_, err := config.Load(configPath)
+	if err == nil {
+		t.Fatal("Load succeeded, want validation errors")
+	}
+
+	want := configPath + `: getterPrefix: must not be empty, since getters can't be named like their fields
+copy: invalid copy mode "always", expected one of none, shallow or deep
+packages["["]: invalid pattern: syntax error in pattern
+packages["["].deref: invalid deref policy "sometimes", expected auto or never
+packages["["].structs["User"].receiver: "_" is not a valid receiver name
+packages["["].structs["User"].paths: depth -1 must not be negative`
+	if err.Error() != want {
+		t.Errorf("Load error:\n%s\nwant:\n%s", err, want)
+	}
+}
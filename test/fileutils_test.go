@@ -0,0 +1,91 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/renxzen/go-getters/pkg/fileutils"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "getters.gen.go")
+
+	write := func(content string, wantWritten bool) {
+		t.Helper()
+		written, err := fileutils.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("WriteFile error: %v", err)
+		}
+		if written != wantWritten {
+			t.Errorf("WriteFile(%q) written = %t, want %t", content, written, wantWritten)
+		}
+		if got, err := os.ReadFile(path); err != nil || string(got) != content {
+			t.Errorf("content = %q, %v, want %q", got, err, content)
+		}
+	}
+
+	write("package a\n", true)
+
+	// A new file gets the mode os.WriteFile would give it, masked by the umask
+	reference := filepath.Join(t.TempDir(), "reference.go")
+	if err := os.WriteFile(reference, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if want, err := os.Stat(reference); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != want.Mode().Perm() {
+		t.Errorf("mode = %v, want %v", info.Mode().Perm(), want.Mode().Perm())
+	}
+
+	// Unchanged content leaves the file untouched
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	write("package a\n", false)
+	if info, err := os.Stat(path); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("modification time changed for unchanged content")
+	}
+
+	// The mode of an existing file is kept
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	write("package b\n", true)
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+
+	// Symbolic links are followed rather than replaced
+	link := filepath.Join(dir, "link.go")
+	if err := os.Symlink(path, link); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	if _, err := fileutils.WriteFile(link, []byte("package c\n"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("link was replaced")
+	}
+	write("package c\n", false)
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("directory holds %q, want the file and the link", names)
+	}
+}
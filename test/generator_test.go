@@ -2,13 +2,18 @@ package test
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/renxzen/go-getters/pkg/diag"
 	"github.com/renxzen/go-getters/pkg/generator"
 	"github.com/renxzen/go-getters/pkg/parser"
+	"github.com/renxzen/go-getters/pkg/types"
 )
 
 var update = flag.Bool("update", false, "update golden files")
@@ -16,8 +21,12 @@ var update = flag.Bool("update", false, "update golden files")
 func TestGenerateGetters(t *testing.T) {
 	tests := []struct {
 		name       string
+		dir        string // Directory of the package, testdata by default
 		structName string
+		structs    []string // Further structs generated after structName
 		goldenFile string
+		options    []generator.Option
+		warnings   []string
 	}{
 		{
 			name:       "basic_example",
@@ -49,23 +58,269 @@ func TestGenerateGetters(t *testing.T) {
 			structName: "Maps",
 			goldenFile: "map_types.golden",
 		},
+		{
+			name:       "copy_fields",
+			structName: "Copies",
+			goldenFile: "copy_fields.golden",
+			options:    []generator.Option{generator.WithCopyMode(types.CopyShallow)},
+		},
+		{
+			name:       "iterators",
+			structName: "Iterators",
+			goldenFile: "iterators.golden",
+			options:    []generator.Option{generator.WithIterators(true)},
+		},
+		{
+			name:       "accessors",
+			structName: "Accessors",
+			goldenFile: "accessors.golden",
+			options:    []generator.Option{generator.WithAccessors(true)},
+		},
+		{
+			name:       "deep_accessors",
+			structName: "Vector",
+			goldenFile: "deep_accessors.golden",
+			options: []generator.Option{
+				generator.WithCopyMode(types.CopyDeep),
+				generator.WithAccessors(true),
+			},
+		},
+		{
+			name:       "deep_iterators",
+			structName: "Vector",
+			goldenFile: "deep_iterators.golden",
+			options: []generator.Option{
+				generator.WithCopyMode(types.CopyDeep),
+				generator.WithIterators(true),
+			},
+		},
+		{
+			name:       "guarded_deep_iterators",
+			structName: "Registry",
+			goldenFile: "guarded_deep_iterators.golden",
+			options: []generator.Option{
+				generator.WithCopyMode(types.CopyDeep),
+				generator.WithIterators(true),
+			},
+		},
+		{
+			name:       "guarded_fields",
+			structName: "Guarded",
+			goldenFile: "guarded_fields.golden",
+			options: []generator.Option{
+				generator.WithIterators(true),
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+			},
+		},
+		{
+			name:       "embedded_lock",
+			structName: "EmbeddedLock",
+			goldenFile: "embedded_lock.golden",
+			options:    []generator.Option{generator.WithSetters(true)},
+		},
+		{
+			name:       "tagged_lock",
+			structName: "TaggedLock",
+			goldenFile: "tagged_lock.golden",
+			options:    []generator.Option{generator.WithCopyMode(types.CopyShallow)},
+		},
+		{
+			name:       "atomic_fields",
+			structName: "Atomics",
+			goldenFile: "atomic_fields.golden",
+			options:    []generator.Option{generator.WithSetters(true)},
+			warnings: []string{
+				"Atomics.Group: no methods generated, since its type sync.WaitGroup holds a value that must not be copied, like a mutex",
+				"Atomics.Guarded: no methods generated, since its type Guarded holds a value that must not be copied, like a mutex",
+				"Atomics.Embedded: no methods generated, since its type EmbeddedLock holds a value that must not be copied, like a mutex",
+			},
+		},
+		{
+			name:       "nullable_fields",
+			structName: "Nullables",
+			goldenFile: "nullable_fields.golden",
+			options: []generator.Option{generator.WithNullableTypes(types.NullableType{
+				Type:       "NullID",
+				ValueField: "ID",
+				ValueType:  "int64",
+				ValidField: "Set",
+			})},
+		},
+		{
+			name:       "interfaces",
+			structName: "Accessors",
+			goldenFile: "interfaces.golden",
+			options: []generator.Option{
+				generator.WithInterfaces(true, "{{.Name}}Getter"),
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+			},
+		},
+		{
+			name:       "field_metadata",
+			structName: "Tagged",
+			goldenFile: "field_metadata.golden",
+			options:    []generator.Option{generator.WithFieldMetadata(true)},
+		},
+		{
+			name:       "field_access",
+			structName: "FieldAccess",
+			goldenFile: "field_access.golden",
+			options: []generator.Option{
+				generator.WithFieldAccess(true),
+				generator.WithSetters(true),
+			},
+			warnings: []string{
+				"FieldAccess.Group: no methods generated, since its type sync.WaitGroup holds a value that must not be copied, like a mutex",
+			},
+		},
+		{
+			name:       "path_getters",
+			structName: "Outer",
+			goldenFile: "path_getters.golden",
+			options:    []generator.Option{generator.WithPathGetters(2)},
+		},
+		{
+			name:       "path_getters_prefix",
+			structName: "Outer",
+			goldenFile: "path_getters_prefix.golden",
+			options: []generator.Option{
+				generator.WithPathGetters(1),
+				generator.WithMethodPrefixes("Read", ""),
+			},
+		},
+		{
+			name:       "path_getters_leaves",
+			structName: "Profile",
+			goldenFile: "path_getters_leaves.golden",
+			options: []generator.Option{
+				generator.WithPathGetters(1),
+				generator.WithCopyMode(types.CopyShallow),
+			},
+		},
+		{
+			name:       "import_collisions",
+			dir:        filepath.Join("testdata", "collisions"),
+			structName: "Lookup",
+			goldenFile: "import_collisions.golden",
+			options: []generator.Option{
+				generator.WithCopyMode(types.CopyShallow),
+				generator.WithIterators(true),
+			},
+		},
+		{
+			name:       "custom_template",
+			structName: "DynamicImports",
+			goldenFile: "custom_template.golden",
+			options:    []generator.Option{generator.WithTemplateFiles(filepath.Join("testdata", "custom.tmpl"))},
+		},
+		{
+			name:       "composed_template",
+			structName: "Example",
+			goldenFile: "composed_template.golden",
+			options:    []generator.Option{generator.WithTemplateFiles(filepath.Join("testdata", "composed.tmpl"))},
+		},
+		{
+			name:       "doc_comments",
+			structName: "Documented",
+			goldenFile: "doc_comments.golden",
+			options: []generator.Option{
+				generator.WithDocComments(true),
+				generator.WithIterators(true),
+				generator.WithSetters(true),
+			},
+		},
+		{
+			name:       "deprecated_fields",
+			structName: "Documented",
+			structs:    []string{"Profile"},
+			goldenFile: "deprecated_fields.golden",
+			options: []generator.Option{
+				generator.WithDeprecationHook("log/slog.Warn"),
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+				generator.WithPathGetters(1),
+			},
+		},
+		{
+			name:       "value_receivers",
+			structName: "Money",
+			goldenFile: "value_receivers.golden",
+			options: []generator.Option{
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+			},
+			warnings: []string{
+				"Money: setters keep pointer receivers, mixed with value receiver getters",
+				"Money: value receiver getters are mixed with the pointer receiver methods Add",
+			},
+		},
+		{
+			name:       "receiver_directive",
+			structName: "Account",
+			goldenFile: "receiver_directive.golden",
+			options: []generator.Option{
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+				generator.WithFieldAccess(true),
+			},
+		},
+		{
+			name:       "output_package",
+			structName: "Slices",
+			goldenFile: "output_package.golden",
+			options: []generator.Option{
+				generator.WithOutputPackage("accessors", "github.com/renxzen/go-getters/test/testdata"),
+				generator.WithDocComments(true),
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+			},
+		},
+		{
+			name:       "receiver_collision",
+			structName: "Timer",
+			goldenFile: "receiver_collision.golden",
+			warnings: []string{
+				"Timer: using receiver x instead of t from its methods, which collides with an imported package",
+			},
+		},
+		{
+			name:       "receiver_locals",
+			structName: "Vector",
+			goldenFile: "receiver_locals.golden",
+			options: []generator.Option{
+				generator.WithCopyMode(types.CopyDeep),
+				generator.WithAccessors(true),
+				generator.WithSetters(true),
+				generator.WithFieldAccess(true),
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.dir
+			if dir == "" {
+				dir = "testdata"
+			}
+
 			p := parser.New()
-			result, err := p.ParseDirectory("testdata")
+			result, err := p.ParseDirectory(dir)
 			if err != nil {
 				t.Fatalf("Failed to parse directory: %v", err)
 			}
 
-			// Generate getters from testdata directory
-			gen := generator.New()
-			structNames := []string{tt.structName}
+			// Generate getters from the package directory
+			gen := generator.New(tt.options...)
+			structNames := append([]string{tt.structName}, tt.structs...)
 			outBytes, err := gen.GenerateGetters(structNames, result)
 			if err != nil {
 				t.Fatalf("GenerateGetters failed: %v", err)
 			}
+			if warnings := gen.Warnings(); !slices.Equal(warnings, tt.warnings) {
+				t.Errorf("Warnings = %q, want %q", warnings, tt.warnings)
+			}
 
 			goldenPath := filepath.Join("testdata", tt.goldenFile)
 
@@ -93,3 +348,115 @@ func TestGenerateGetters(t *testing.T) {
 		})
 	}
 }
+
+func TestMissingStructs(t *testing.T) {
+	p := parser.New()
+	result, err := p.ParseDirectory("testdata")
+	if err != nil {
+		t.Fatalf("Failed to parse directory: %v", err)
+	}
+
+	gen := generator.New()
+	_, err = gen.GenerateGetters([]string{"Mony", "Example", "Cash", "Token", "Source", "Nothing"}, result)
+	if err == nil {
+		t.Fatal("GenerateGetters succeeded, want errors")
+	}
+
+	want := strings.Join([]string{
+		"struct Mony not found; did you mean Money (testdata/structs.go:193:6)?",
+		"Cash is not a struct: it is an alias of Money (testdata/structs.go:219:6); use Money (testdata/structs.go:193:6) instead",
+		"Token is not a struct: it is a type defined as string (testdata/structs.go:221:6)",
+		"Source is not a struct: it is an interface type (testdata/structs.go:223:6)",
+		"struct Nothing not found",
+	}, "\n")
+	if err.Error() != want {
+		t.Errorf("GenerateGetters error:\n%s\nwant:\n%s", err, want)
+	}
+
+	var structErr *generator.StructError
+	if !errors.As(err, &structErr) || structErr.Name != "Mony" {
+		t.Errorf("GenerateGetters error = %#v, want a *generator.StructError for Mony", err)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	p := parser.New()
+	result, err := p.ParseDirectory("testdata")
+	if err != nil {
+		t.Fatalf("Failed to parse directory: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		diags func() []diag.Diagnostic
+		want  []string
+	}{
+		{
+			name:  "parser",
+			diags: p.Diagnostics,
+			want: []string{
+				`testdata/structs.go:229:17: warning: unknown option "frozen" in getter tag of field Mode [unknown-option]`,
+				`testdata/structs.go:230:2: warning: unknown directive "//getters:readonly" on field Limit [unknown-option]`,
+			},
+		},
+		{
+			name: "unsupported type",
+			diags: func() []diag.Diagnostic {
+				gen := generator.New()
+				if _, err := gen.GenerateGetters([]string{"Callbacks"}, result); err != nil {
+					t.Errorf("GenerateGetters error: %v", err)
+				}
+				return gen.Diagnostics()
+			},
+			want: []string{
+				`testdata/structs.go:228:2: warning: Callbacks.OnDone: type func() error is not supported, the generated methods use any instead [unsupported-type]`,
+				`testdata/structs.go:229:2: info: Callbacks.GetMode returns "" both when Mode is nil and when it points to ""; use -deref=never to tell them apart [deref-ambiguity]`,
+			},
+		},
+		{
+			name: "name conflicts",
+			diags: func() []diag.Diagnostic {
+				gen := generator.New()
+				if _, err := gen.GenerateGetters([]string{"Named"}, result); err == nil {
+					t.Error("GenerateGetters succeeded, want errors")
+				}
+				return gen.Diagnostics()
+			},
+			want: []string{
+				`testdata/structs.go:235:2: error: Named: generated method GetName conflicts with the method declared at testdata/structs.go:241:17 [name-conflict]`,
+				`testdata/structs.go:237:2: error: Named: generated method GetLabel conflicts with the field declared at testdata/structs.go:236:2 [name-conflict]`,
+				`testdata/structs.go:238:2: error: Named: method GetLabel is generated twice [name-conflict]`,
+			},
+		},
+		{
+			name: "output package",
+			diags: func() []diag.Diagnostic {
+				gen := generator.New(generator.WithOutputPackage("accessors", "github.com/renxzen/go-getters/test/testdata"), generator.WithInterfaces(true, ""))
+				if _, err := gen.GenerateGetters([]string{"Handle", "TaggedLock"}, result); err == nil {
+					t.Error("GenerateGetters succeeded, want errors")
+				}
+				return gen.Diagnostics()
+			},
+			want: []string{
+				`testdata/structs.go:246:2: warning: Handle.Gate: skipped, since its type *gate refers to unexported types [output-package]`,
+				`testdata/structs.go:120:2: error: TaggedLock: its unexported lock guard can't be held by package accessors [output-package]`,
+				`testdata/structs.go:245:6: warning: Handle: interfaces are not generated in package accessors [output-package]`,
+				`testdata/structs.go:119:6: warning: TaggedLock: interfaces are not generated in package accessors [output-package]`,
+				`testdata/structs.go:121:2: info: TaggedLock.GetItems returns nil both when Items is nil and when it points to nil; use -deref=never to tell them apart [deref-ambiguity]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range tt.diags() {
+				got = append(got, d.String())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/renxzen/go-getters/pkg/inspect"
+	"github.com/renxzen/go-getters/pkg/parser"
+)
+
+func TestInspect(t *testing.T) {
+	p := parser.New()
+	result, err := p.ParseDirectory("testdata")
+	if err != nil {
+		t.Fatalf("Failed to parse directory: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := inspect.Write(&buf, result); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	var model inspect.Model
+	if err := json.Unmarshal(buf.Bytes(), &model); err != nil {
+		t.Fatalf("Failed to decode the model: %v", err)
+	}
+
+	if model.SchemaVersion != inspect.SchemaVersion || model.Package != "testdata" {
+		t.Errorf("schemaVersion = %d, package = %q, want %d and testdata", model.SchemaVersion, model.Package, inspect.SchemaVersion)
+	}
+
+	structs := make(map[string]inspect.Struct)
+	for _, s := range model.Structs {
+		structs[s.Name] = s
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "Slices",
+			want: `{"name":"Slices","position":{"file":"testdata/structs.go","line":39,"column":6},"fields":[` +
+				`{"name":"SlicePtr","position":{"file":"testdata/structs.go","line":40,"column":2},"type":"*[]Example","underlyingType":"[]Example","skip":false,"isPointer":true,"isExported":true,"isSlice":true,"isMap":false,"isEmbedded":false,` +
+				`"elem":{"type":"Example","underlyingType":"Example","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},"unguarded":false,"noCopy":false},` +
+				`{"name":"Slice","position":{"file":"testdata/structs.go","line":41,"column":2},"type":"[]Example","underlyingType":"[]Example","skip":false,"isPointer":false,"isExported":true,"isSlice":true,"isMap":false,"isEmbedded":false,` +
+				`"elem":{"type":"Example","underlyingType":"Example","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},"unguarded":false,"noCopy":false},` +
+				`{"name":"SliceInt","position":{"file":"testdata/structs.go","line":42,"column":2},"type":"[]int","underlyingType":"[]int","skip":false,"isPointer":false,"isExported":true,"isSlice":true,"isMap":false,"isEmbedded":false,` +
+				`"elem":{"type":"int","underlyingType":"int","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},"unguarded":false,"noCopy":false},` +
+				`{"name":"SliceStr","position":{"file":"testdata/structs.go","line":43,"column":2},"type":"[]string","underlyingType":"[]string","skip":false,"isPointer":false,"isExported":true,"isSlice":true,"isMap":false,"isEmbedded":false,` +
+				`"elem":{"type":"string","underlyingType":"string","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},"unguarded":false,"noCopy":false},` +
+				`{"name":"SliceBool","position":{"file":"testdata/structs.go","line":44,"column":2},"type":"[]bool","underlyingType":"[]bool","skip":false,"isPointer":false,"isExported":true,"isSlice":true,"isMap":false,"isEmbedded":false,` +
+				`"elem":{"type":"bool","underlyingType":"bool","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},"unguarded":false,"noCopy":false}` +
+				`],"methods":[],"valueReceiver":false}`,
+		},
+		{
+			name: "Account",
+			want: `{"name":"Account","position":{"file":"testdata/structs.go","line":205,"column":6},"fields":[` +
+				`{"name":"mu","position":{"file":"testdata/structs.go","line":206,"column":2},"type":"sync.RWMutex","underlyingType":"sync.RWMutex","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"requiredImports":["sync"],"lock":"rwmutex","unguarded":false,"noCopy":true},` +
+				`{"name":"Owner","position":{"file":"testdata/structs.go","line":207,"column":2},"type":"string","underlyingType":"string","skip":false,"isPointer":false,"isExported":true,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},` +
+				`{"name":"Tags","position":{"file":"testdata/structs.go","line":208,"column":2},"type":"[]string","underlyingType":"[]string","skip":false,"isPointer":false,"isExported":true,"isSlice":true,"isMap":false,"isEmbedded":false,` +
+				`"elem":{"type":"string","underlyingType":"string","skip":false,"isPointer":false,"isExported":false,"isSlice":false,"isMap":false,"isEmbedded":false,"unguarded":false,"noCopy":false},"unguarded":false,"noCopy":false}` +
+				`],"methods":[{"name":"Close","position":{"file":"testdata/structs.go","line":211,"column":21},"receiver":"acc","isPointer":true}],"valueReceiver":false,"receiver":"a"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(structs[tt.name])
+			if err != nil {
+				t.Fatalf("Failed to encode %s: %v", tt.name, err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("%s:\n%s\nwant:\n%s", tt.name, got, tt.want)
+			}
+		})
+	}
+}
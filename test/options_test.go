@@ -0,0 +1,228 @@
+package test
+
+import (
+	"bytes"
+	"cmp"
+	"flag"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/renxzen/go-getters/pkg/options"
+)
+
+func TestOptionsFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		goldenFile string
+		structs    []string
+		path       string // Defaults to the default output file of testdata
+	}{
+		{
+			name:       "iterators",
+			args:       []string{"-input=testdata", "-structs=Iterators", "-iter"},
+			goldenFile: "iterators.golden",
+			structs:    []string{"Iterators"},
+		},
+		{
+			name:       "value_receivers",
+			args:       []string{"-input=testdata", "-structs", "Mon*", "-accessors", "-setters"},
+			goldenFile: "value_receivers.golden",
+			structs:    []string{"Money"},
+		},
+		{
+			name:       "receiver_directive",
+			args:       []string{"-input=testdata", "-structs=Account", "-accessors", "-setters=true", "-field-access"},
+			goldenFile: "receiver_directive.golden",
+			structs:    []string{"Account"},
+		},
+		{
+			name:       "output_package",
+			args:       []string{"-input=testdata", "-structs=Slices", "-output=accessors/getters.go", "-docs", "-accessors", "-setters"},
+			goldenFile: "output_package.golden",
+			structs:    []string{"Slices"},
+			path:       filepath.Join("testdata", "accessors", "getters.go"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet(tt.name, flag.ContinueOnError)
+			var opts options.Options
+			opts.RegisterFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			res, err := options.Generate(&opts)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if want := cmp.Or(tt.path, filepath.Join("testdata", options.DefaultOutput)); res.Path != want {
+				t.Errorf("Path = %q, want %q", res.Path, want)
+			}
+			if !slices.Equal(res.Structs, tt.structs) {
+				t.Errorf("Structs = %q, want %q", res.Structs, tt.structs)
+			}
+
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.goldenFile))
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			if !bytes.Equal(res.Code, expected) {
+				t.Errorf("Generated output doesn't match golden file %s", tt.goldenFile)
+				t.Errorf("Got:\n%s", res.Code)
+			}
+		})
+	}
+}
+
+func TestOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "invalid_copy_mode",
+			args: []string{"-copy=all"},
+			err:  `invalid value "all" for flag -copy: invalid copy mode "all", expected one of none, shallow or deep`,
+		},
+		{
+			name: "empty_getter_prefix",
+			args: []string{"-getter-prefix="},
+			err:  `invalid value "" for flag -getter-prefix: "" is not a valid identifier`,
+		},
+		{
+			name: "no_structs",
+			args: []string{"-input=testdata"},
+			err:  options.ErrNoStructs.Error(),
+		},
+		{
+			name: "missing_struct",
+			args: []string{"-input=testdata", "-structs=Mony"},
+			err:  "failed to generate getters: struct Mony not found; did you mean Money (testdata/structs.go:193:6)?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet(tt.name, flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			var opts options.Options
+			opts.RegisterFlags(fs)
+
+			err := fs.Parse(tt.args)
+			if err == nil {
+				_, err = options.Generate(&opts)
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("error = %v, want %s", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestGoGenerateEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		env     map[string]string
+		structs []string
+		err     string
+	}{
+		{
+			name:    "struct_after_directive",
+			env:     map[string]string{"GOFILE": "structs.go", "GOLINE": "192", "GOPACKAGE": "testdata"},
+			structs: []string{"Money"},
+		},
+		{
+			name: "func_after_directive",
+			env:  map[string]string{"GOFILE": "structs.go", "GOLINE": "199", "GOPACKAGE": "testdata"},
+			err:  options.ErrNoStructs.Error(),
+		},
+		{
+			name: "other_package",
+			env:  map[string]string{"GOFILE": "structs.go", "GOLINE": "192", "GOPACKAGE": "testdata_test"},
+			err:  "failed to parse directory: no package testdata_test in directory .",
+		},
+		{
+			name:  "other_directory",
+			input: "..",
+			env:   map[string]string{"GOFILE": "structs.go", "GOLINE": "192", "GOPACKAGE": "testdata"},
+			err:   options.ErrNoStructs.Error(),
+		},
+	}
+
+	t.Chdir("testdata")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options.Options{Input: tt.input}
+			opts.ReadGoGenerateEnv(func(key string) string { return tt.env[key] })
+
+			res, err := options.Generate(&opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Generate error = %v, want %s", err, tt.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if !slices.Equal(res.Structs, tt.structs) {
+				t.Errorf("Structs = %q, want %q", res.Structs, tt.structs)
+			}
+		})
+	}
+}
+
+func TestBareDirectives(t *testing.T) {
+	dir := t.TempDir()
+	src := `package models
+
+//go:generate go-getters
+type User struct {
+	Name string
+}
+
+//go:generate go-getters
+type Order struct {
+	ID int
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(dir)
+	written := make(map[string]string)
+	for _, line := range []int{3, 8} {
+		opts := options.Options{}
+		env := map[string]string{"GOFILE": "models.go", "GOLINE": strconv.Itoa(line), "GOPACKAGE": "models"}
+		opts.ReadGoGenerateEnv(func(key string) string { return env[key] })
+
+		res, err := options.Generate(&opts)
+		if err != nil {
+			t.Fatalf("Generate failed for the directive of line %d: %v", line, err)
+		}
+		if other, exists := written[res.Path]; exists {
+			t.Fatalf("directives of %s and %s write to the same file %s", other, res.Structs[0], res.Path)
+		}
+		written[res.Path] = res.Structs[0]
+	}
+
+	want := map[string]string{
+		"models_user_getters.gen.go":  "User",
+		"models_order_getters.gen.go": "Order",
+	}
+	if !maps.Equal(written, want) {
+		t.Errorf("written files = %v, want %v", written, want)
+	}
+}
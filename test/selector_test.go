@@ -0,0 +1,102 @@
+package test
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/renxzen/go-getters/pkg/parser"
+	"github.com/renxzen/go-getters/pkg/selector"
+)
+
+func TestSelectStructs(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector selector.Selector
+		want     []string
+		warnings []string
+		err      string
+		tests    bool // Whether _test.go files are parsed
+	}{
+		{
+			name:     "names_and_patterns",
+			selector: selector.Selector{Patterns: []string{"Money", "*Lock", "Money"}},
+			want:     []string{"Money", "EmbeddedLock", "TaggedLock"},
+		},
+		{
+			name: "exclude",
+			selector: selector.Selector{
+				Patterns:       []string{"*Lock", "Null*"},
+				Exclude:        []string{"NullID"},
+				ExcludeRegexps: []*regexp.Regexp{regexp.MustCompile("^Tagged")},
+			},
+			want: []string{"EmbeddedLock", "Nullables"},
+		},
+		{
+			name:     "all_unexported",
+			selector: selector.Selector{All: true, Visibility: selector.VisibilityUnexported},
+			want:     []string{"gate"},
+		},
+		{
+			name:     "local_and_test_structs",
+			selector: selector.Selector{Patterns: []string{"local", "Fixture"}, Lenient: true},
+			warnings: []string{`no struct matches "local"`, `no struct matches "Fixture"`},
+		},
+		{
+			name:     "test_structs",
+			selector: selector.Selector{Patterns: []string{"Fixture"}},
+			want:     []string{"Fixture"},
+			tests:    true,
+		},
+		{
+			name:     "missing_name",
+			selector: selector.Selector{Patterns: []string{"Missing"}},
+			want:     []string{"Missing"},
+		},
+		{
+			name:     "unmatched_pattern",
+			selector: selector.Selector{Patterns: []string{"*Request", "Money", "*Response"}},
+			err:      `no struct matches "*Request", "*Response"`,
+		},
+		{
+			name:     "lenient",
+			selector: selector.Selector{Patterns: []string{"*Request", "Missing", "Money"}, Lenient: true},
+			want:     []string{"Money"},
+			warnings: []string{`no struct matches "*Request"`, `no struct matches "Missing"`},
+		},
+		{
+			name:     "invalid_pattern",
+			selector: selector.Selector{Patterns: []string{"[Money"}},
+			err:      `invalid struct pattern "[Money": syntax error in pattern`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.New()
+			p.IncludeTests(tt.tests)
+			result, err := p.ParseDirectory("testdata")
+			if err != nil {
+				t.Fatalf("Failed to parse directory: %v", err)
+			}
+
+			names, warnings, err := tt.selector.Select(result.Structs)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Select error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("Select = %q, want %q", names, tt.want)
+			}
+			if !slices.Equal(warnings, tt.warnings) {
+				t.Errorf("Warnings = %q, want %q", warnings, tt.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package collisions
+
+import "github.com/renxzen/go-getters/test/testdata/collisions/maps"
+
+// Lookup refers to a local package named like a package the generated code
+// imports.
+type Lookup struct {
+	Counts map[maps.Key]int
+	Keys   []maps.Key
+}
@@ -0,0 +1,5 @@
+// Package maps is named like the maps package of the standard library, which
+// the generated code imports as well.
+package maps
+
+type Key string
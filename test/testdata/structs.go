@@ -8,10 +8,13 @@ import (
 	"crypto/sha1"
 	"crypto/tls"
 	"crypto/x509"
+	"database/sql"
 	"math"
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
+	"sync/atomic"
 	t "time"
 )
 
@@ -63,3 +66,209 @@ type Maps struct {
 	ImportedPtrValue               map[uint8]*http.Request
 	ImportedPtrKeyImportedPtrValue map[*os.FileMode]*list.Element
 }
+
+type Copies struct {
+	Items    []Example
+	Tags     map[string]string
+	ItemsPtr *[]Example
+	TagsPtr  *map[string]int
+	Grid     [][]int             `getter:"deepcopy"`
+	Index    map[string][]string `getter:"deepcopy"`
+	Nested   []map[string][]int  `getter:"deepcopy"`
+	Raw      []byte              `getter:"nocopy"`
+	Name     string
+}
+
+type Iterators struct {
+	Items    []Example
+	ItemsPtr *[]Example
+	Tags     map[string]string
+	TagsPtr  *map[crypto.Hash]*url.URL
+	Lists    []*list.List
+	Name     string
+}
+
+type Accessors struct {
+	Items       []Example
+	ItemsPtr    *[]Example
+	Entries     []*list.List
+	ImportedKey map[crypto.Hash]string
+	TagsPtr     *map[string]*url.URL
+	Name        string
+}
+
+type Guarded struct {
+	mu     sync.RWMutex
+	Name   string
+	Age    *int
+	Tags   []string
+	Counts map[string]int
+	//getters:unguarded
+	ID int // immutable after construction
+}
+
+type EmbeddedLock struct {
+	sync.Mutex
+	Count int
+}
+
+type gate struct {
+	sync.Mutex
+}
+
+type TaggedLock struct {
+	guard gate `getter:"lock"`
+	Items *[]Example
+}
+
+type Atomics struct {
+	mu       sync.Mutex
+	Count    atomic.Int64
+	Ready    atomic.Bool
+	Current  atomic.Pointer[url.URL]
+	Config   atomic.Value
+	Shared   *atomic.Uint32
+	Name     string
+	Group    sync.WaitGroup
+	Guarded  Guarded
+	Ptr      *Guarded
+	Embedded EmbeddedLock
+}
+
+type NullID struct {
+	ID  int64
+	Set bool
+}
+
+type Nullables struct {
+	Name      sql.NullString
+	Age       sql.NullInt64
+	Deleted   sql.NullTime
+	CreatedAt sql.Null[t.Time]
+	Score     *sql.NullFloat64
+	Owner     NullID
+}
+
+type Tagged struct {
+	ID        int     `json:"id"`
+	Name      *string `json:"name,omitempty"`
+	Email     string  `json:"-"`
+	Internal  string
+	CreatedAt t.Time   `json:"created_at" db:"created_at"`
+	Tags      []string `json:"tags" getter:"copy"`
+	hidden    bool
+}
+
+type FieldAccess struct {
+	ID       int
+	Name     *string
+	Secret   string  `getter:"-"`
+	URL      url.URL `getter:"name=Link"`
+	Visits   atomic.Int64
+	Nickname sql.NullString
+	Group    sync.WaitGroup
+	internal int
+}
+
+type Outer struct {
+	Container *Container
+	Pointer   Pointer
+	Guarded   *Guarded
+	Label     string
+}
+
+type Documented struct {
+	// Email is the verified address
+	Email string
+	// Name of the user.
+	//
+	// Deprecated: use Email instead.
+	Name string
+	Age  int // age in years
+	Tags []string
+	Nick sql.NullString
+}
+
+//getters:value
+type Money struct {
+	Amount   int64
+	Currency string
+	Note     *string
+	Rates    map[string]float64
+}
+
+func (m *Money) Add(amount int64) {
+	m.Amount += amount
+}
+
+//getters:receiver=a
+type Account struct {
+	mu    sync.RWMutex
+	Owner string
+	Tags  []string
+}
+
+func (acc *Account) Close() {}
+
+type Timer struct {
+	Start t.Time
+}
+
+func (t *Timer) Reset() {}
+
+type Cash = Money
+
+type Token string
+
+type Source interface {
+	Read() ([]byte, error)
+}
+
+type Callbacks struct {
+	OnDone func() error
+	Mode   *string `getter:"nocopy,frozen"`
+	//getters:readonly
+	Limit int
+}
+
+type Named struct {
+	Name     string
+	GetLabel string
+	Label    string
+	Title    string `getter:"name=Label"`
+}
+
+func (n *Named) GetName() string {
+	return n.Name
+}
+
+type Handle struct {
+	Gate *gate
+	Name string
+}
+
+func newLocal() any {
+	type local struct {
+		Name string
+	}
+	return local{}
+}
+
+type Profile struct {
+	User *Documented
+	Note string
+}
+
+type Vector struct {
+	Coords []float64
+	Groups [][]int
+	Labels map[string]string
+	Tags   map[string][]string
+}
+
+func (v *Vector) Len() int { return len(v.Coords) }
+
+type Registry struct {
+	mu     sync.RWMutex
+	Groups map[string][]string
+}
@@ -0,0 +1,5 @@
+package testdata
+
+type Fixture struct {
+	Name string
+}